@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	bpb "github.com/dgraph-io/badger/v4/pb"
+
+	"github.com/yiminan/go-examples/go-badger-db-and-grpc/calendar"
+	"github.com/yiminan/go-examples/go-ratelimit"
+)
+
+// 종목 키 형식 (stock:<YYYYMMDD>:<종목코드>)
+const stockKeyPrefix = "stock:"
+
+// 변경 구독이 등록되었는지 확인할 때 쓰는 키
+const cacheProbeKey = "cache:probe"
+
+// 장이 끝난 거래일 데이터를 HTTP 캐시(KrakenD 등)에 보관할 시간
+const closedDayMaxAge = 24 * time.Hour
+
+// 거래일 판단 기준 시간대
+var seoul = calendar.KST
+
+// CacheStats 는 캐시 지표입니다 (/cache/stats)
+type CacheStats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Evictions     int64 `json:"evictions"`     // 용량 초과로 제거된 항목 수
+	Invalidations int64 `json:"invalidations"` // Badger 변경 구독으로 제거된 항목 수
+	Entries       int   `json:"entries"`
+	Bytes         int64 `json:"bytes"`
+	MaxBytes      int64 `json:"maxBytes"`
+}
+
+// 캐시 항목
+type cacheEntry struct {
+	key   string
+	value string // 압축을 푼 문서 (요청마다 복사하지 않고 공유하도록 불변 문자열로 보관)
+}
+
+// valueCache 는 조회한 값을 바이트 크기 한도 안에서 보관하는 LRU 캐시입니다
+type valueCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	ll       *list.List // 앞쪽이 최근 사용
+	items    map[string]*list.Element
+	loads    map[string]*cacheLoad // DB 에서 읽는 중인 키
+	stats    CacheStats
+}
+
+// 키 하나를 DB 에서 읽는 중인 조회들
+type cacheLoad struct {
+	readers int
+	gen     uint64 // 읽는 동안 키가 바뀔 때마다 증가
+}
+
+// 조회 결과 캐시 (nil 이면 사용 안 함)
+var cache *valueCache
+
+func newValueCache(maxBytes int64) *valueCache {
+	return &valueCache{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+		loads:    make(map[string]*cacheLoad),
+		stats:    CacheStats{MaxBytes: maxBytes},
+	}
+}
+
+func entrySize(key, value string) int64 {
+	return int64(len(key) + len(value))
+}
+
+// 캐시에서 값을 찾습니다
+// 없으면 DB 에서 읽기 시작한 것으로 기록하고, 읽은 값을 넣을 때(add) 또는 읽지 못했을 때(abort) 넘길 세대를 반환
+func (c *valueCache) get(key string) (string, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		c.stats.Hits++
+		return el.Value.(*cacheEntry).value, 0, true
+	}
+	c.stats.Misses++
+	l, ok := c.loads[key]
+	if !ok {
+		l = &cacheLoad{}
+		c.loads[key] = l
+	}
+	l.readers++
+	return "", l.gen, false
+}
+
+// 읽기를 마친 것으로 기록하고, 읽는 동안 키가 바뀌었는지 반환합니다 (mu 를 잡은 상태에서 호출)
+func (c *valueCache) endLoad(key string, gen uint64) bool {
+	l, ok := c.loads[key]
+	if !ok {
+		return false
+	}
+	if l.readers--; l.readers == 0 {
+		delete(c.loads, key)
+	}
+	return l.gen != gen
+}
+
+// DB 에서 읽지 못했을 때 읽기를 마친 것으로 기록합니다
+func (c *valueCache) abort(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.endLoad(key, 0)
+}
+
+// DB 에서 읽은 값을 넣습니다
+// 읽는 동안 변경 구독으로 그 키가 지워졌다면(세대 변경) 오래된 값일 수 있으므로 넣지 않음
+func (c *valueCache) add(key, value string, gen uint64) {
+	size := entrySize(key, value)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.endLoad(key, gen) || size > c.maxBytes {
+		return
+	}
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+	c.items[key] = c.ll.PushFront(&cacheEntry{key: key, value: value})
+	c.stats.Bytes += size
+	for c.stats.Bytes > c.maxBytes {
+		c.removeElement(c.ll.Back())
+		c.stats.Evictions++
+	}
+}
+
+// 키가 변경되거나 삭제되었을 때 항목을 지웁니다 (그 키를 읽는 중인 조회의 값도 넣지 않도록 함)
+func (c *valueCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if l, ok := c.loads[key]; ok {
+		l.gen++
+	}
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+		c.stats.Invalidations++
+	}
+}
+
+// 항목을 지웁니다 (mu 를 잡은 상태에서 호출)
+func (c *valueCache) removeElement(el *list.Element) {
+	e := c.ll.Remove(el).(*cacheEntry)
+	delete(c.items, e.key)
+	c.stats.Bytes -= entrySize(e.key, e.value)
+}
+
+func (c *valueCache) snapshot() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := c.stats
+	s.Entries = c.ll.Len()
+	return s
+}
+
+// Badger 변경 구독으로 바뀐 키를 캐시에서 지웁니다 (ctx 가 끝날 때까지 실행)
+// 레이트 리밋 카운터는 조회 대상이 아니고 자주 바뀌므로 무시하며, 확인용 키를 받으면 ready 를 닫습니다
+func (c *valueCache) watch(ctx context.Context, ready chan<- struct{}) error {
+	var once sync.Once
+	return db.Subscribe(ctx, func(kvs *badger.KVList) error {
+		for _, kv := range kvs.Kv {
+			switch {
+			case string(kv.Key) == cacheProbeKey:
+				once.Do(func() { close(ready) })
+			case bytes.HasPrefix(kv.Key, []byte(ratelimit.KeyPrefix)):
+			default:
+				c.invalidate(string(kv.Key))
+			}
+		}
+		return nil
+	}, []bpb.Match{{Prefix: nil}})
+}
+
+// 키의 값을 캐시 또는 DB 에서 읽습니다 (없으면 badger.ErrKeyNotFound)
+// stock:latest:<종목코드> 는 거래 달력으로 가장 최근 거래일의 스냅샷을 찾아 읽습니다
+func loadValue(key string) (string, error) {
+	key, err := resolveStockKey(key, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	var gen uint64
+	if cache != nil {
+		var (
+			value string
+			ok    bool
+		)
+		if value, gen, ok = cache.get(key); ok {
+			return value, nil
+		}
+	}
+
+	var decoded []byte
+	err = db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			decoded, err = decodeValue(val)
+			return err
+		})
+	})
+	if err != nil {
+		if cache != nil {
+			cache.abort(key)
+		}
+		return "", err
+	}
+	value := string(decoded)
+	if cache != nil {
+		cache.add(key, value, gen)
+	}
+	return value, nil
+}
+
+// 키에 맞는 Cache-Control 값
+// 장이 끝난 거래일(오늘 이전 날짜)의 종목 데이터는 바뀌지 않으므로 KrakenD 등 공유 캐시가 오래 보관하도록 하고,
+// 오늘 데이터나 그 밖의 키는 매번 확인하도록 합니다
+func cacheControl(key string, now time.Time) string {
+	rest, ok := strings.CutPrefix(key, stockKeyPrefix)
+	if ok && len(rest) > 9 && rest[8] == ':' {
+		day, err := time.ParseInLocation("20060102", rest[:8], seoul)
+		y, m, d := now.In(seoul).Date()
+		if err == nil && day.Before(time.Date(y, m, d, 0, 0, 0, 0, seoul)) {
+			return "public, max-age=" + strconv.Itoa(int(closedDayMaxAge.Seconds()))
+		}
+	}
+	return "no-cache"
+}
+
+// 캐시 지표
+func cacheStatsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if cache == nil {
+		json.NewEncoder(w).Encode(CacheStats{})
+		return
+	}
+	json.NewEncoder(w).Encode(cache.snapshot())
+}
+
+// 캐시를 만들고 변경 구독을 시작합니다 (maxBytes 가 0 이면 캐시를 사용하지 않음)
+// 구독이 등록되기 전의 변경은 받을 수 없으므로, 확인용 키를 써서 구독이 동작할 때까지 기다립니다
+func startCache(ctx context.Context, maxBytes int64) error {
+	if maxBytes <= 0 {
+		return nil
+	}
+	c := newValueCache(maxBytes)
+	ready := make(chan struct{})
+	go func() {
+		if err := c.watch(ctx, ready); err != nil && ctx.Err() == nil {
+			log.Printf("cache subscription stopped: %v", err)
+		}
+	}()
+	if err := waitSubscribed(ready); err != nil {
+		return err
+	}
+	cache = c
+	return nil
+}
+
+// 변경 구독이 확인용 키(cacheProbeKey)를 받아 ready 를 닫을 때까지 확인용 키를 씁니다
+func waitSubscribed(ready <-chan struct{}) error {
+	timeout := time.After(5 * time.Second)
+	for {
+		err := db.Update(func(txn *badger.Txn) error {
+			return txn.SetEntry(newEntry([]byte(cacheProbeKey), nil).WithTTL(time.Minute))
+		})
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ready:
+			return nil
+		case <-time.After(10 * time.Millisecond):
+		case <-timeout:
+			return errors.New("subscription not ready")
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+func TestValueCacheEviction(t *testing.T) {
+	c := newValueCache(30)
+	c.add("a", strings.Repeat("x", 9), 0) // 10 바이트
+	c.add("b", strings.Repeat("x", 9), 0)
+	c.add("c", strings.Repeat("x", 9), 0)
+	if _, _, ok := c.get("a"); !ok { // a 를 최근 사용으로
+		t.Fatal("a 없음")
+	}
+	c.add("d", strings.Repeat("x", 9), 0) // 가장 오래 쓰지 않은 b 제거
+
+	if _, _, ok := c.get("b"); ok {
+		t.Error("b 가 제거되지 않음")
+	}
+	for _, k := range []string{"a", "c", "d"} {
+		if _, _, ok := c.get(k); !ok {
+			t.Errorf("%s 없음", k)
+		}
+	}
+	s := c.snapshot()
+	if s.Bytes != 30 || s.Entries != 3 || s.Evictions != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+
+	// 한도보다 큰 값은 넣지 않음
+	c.add("big", strings.Repeat("x", 40), 0)
+	if _, _, ok := c.get("big"); ok {
+		t.Error("한도보다 큰 값이 들어감")
+	}
+}
+
+func TestValueCacheStaleLoad(t *testing.T) {
+	c := newValueCache(1 << 10)
+	_, epoch, _ := c.get("k")
+	c.invalidate("k") // DB 를 읽는 동안 값이 바뀜
+	c.add("k", "old", epoch)
+	if _, _, ok := c.get("k"); ok {
+		t.Error("읽는 동안 바뀐 값이 캐시됨")
+	}
+	c.abort("k")
+
+	// 다른 키(피드 수집의 체결 기록 등)가 바뀌어도 읽은 값은 캐시됨
+	_, gen, _ := c.get("stock:20250428:KR7005930003")
+	c.invalidate("tick:20250428:KR7005930003:0001")
+	c.add("stock:20250428:KR7005930003", "v", gen)
+	if _, _, ok := c.get("stock:20250428:KR7005930003"); !ok {
+		t.Error("다른 키가 바뀌었는데 캐시되지 않음")
+	}
+	if len(c.loads) != 0 {
+		t.Errorf("읽기 기록이 남음: %v", c.loads)
+	}
+}
+
+func TestCacheControl(t *testing.T) {
+	now := time.Date(2025, 4, 28, 16, 0, 0, 0, seoul)
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"stock:20250425:KR7005930003", "public, max-age=86400"},
+		{"stock:20250428:KR7005930003", "no-cache"}, // 오늘
+		{"stock:2025:KR7005930003", "no-cache"},
+		{"user:1", "no-cache"},
+	}
+	for _, tt := range tests {
+		if got := cacheControl(tt.key, now); got != tt.want {
+			t.Errorf("cacheControl(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	// UTC 로는 전날이어도 한국 시간 기준으로 판단
+	if got := cacheControl("stock:20250428:X", time.Date(2025, 4, 28, 16, 0, 0, 0, time.UTC)); got == "no-cache" {
+		t.Errorf("KST 4/29 01:00 에 4/28 데이터: %q", got)
+	}
+}
+
+func TestGetHandlerCache(t *testing.T) {
+	var err error
+	db, err = badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	initData() // 구독 전에 저장해 아래 조회가 저장 알림과 겹치지 않도록 함
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := startCache(ctx, 1<<20); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { cache = nil }()
+
+	const key = "stock:20250428:KR7005930003"
+	get := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		getHandler(rec, httptest.NewRequest(http.MethodGet, "/get?key="+key, nil))
+		return rec
+	}
+	for i := 0; i < 3; i++ {
+		if rec := get(); rec.Code != http.StatusOK || rec.Header().Get("Cache-Control") != "public, max-age=86400" {
+			t.Fatalf("status %d, Cache-Control %q", rec.Code, rec.Header().Get("Cache-Control"))
+		}
+	}
+	if s := cache.snapshot(); s.Hits != 2 || s.Misses != 1 || s.Entries != 1 {
+		t.Fatalf("stats = %+v", s)
+	}
+
+	// /set 으로 값을 바꾸면 변경 구독이 캐시 항목을 지움
+	rec := httptest.NewRecorder()
+	setHandler(rec, httptest.NewRequest(http.MethodPost, "/set", strings.NewReader(`{"key":"`+key+`","value":"{\"code\":\"changed\"}"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("set: status %d", rec.Code)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for cache.snapshot().Invalidations == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("변경 구독으로 캐시가 무효화되지 않음")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if body := get().Body.String(); !strings.Contains(body, "changed") {
+		t.Errorf("변경 후 응답 = %s", body)
+	}
+}
@@ -0,0 +1,166 @@
+// Package calendar 는 KRX 매매 세션과 휴장일로 장 운영 여부, 다음 세션, 직전 거래일을 계산합니다
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// KST 는 거래일과 세션 시각의 기준 시간대입니다
+var KST = time.FixedZone("KST", 9*60*60)
+
+// Session 은 KRX 매매 세션 하나입니다 (0시부터의 시작/종료 시각)
+type Session struct {
+	Name  string
+	Board string // 세션의 보드 ID (limitPrice, boardId)
+	Start time.Duration
+	End   time.Duration
+}
+
+// Sessions 는 하루의 매매 세션입니다 (시간 순서)
+var Sessions = []Session{
+	{"pre-open", "G2", 8*time.Hour + 30*time.Minute, 9 * time.Hour},                         // 장개시 전 시간외 / 시가 동시호가
+	{"regular", "G1", 9 * time.Hour, 15*time.Hour + 20*time.Minute},                         // 정규장
+	{"closing-auction", "G1", 15*time.Hour + 20*time.Minute, 15*time.Hour + 30*time.Minute}, // 종가 동시호가
+	{"after-hours-close", "G3", 15*time.Hour + 40*time.Minute, 16 * time.Hour},              // 장종료 후 시간외 종가
+	{"after-hours-single", "G4", 16 * time.Hour, 18 * time.Hour},                            // 시간외 단일가
+}
+
+// Period 는 특정 거래일의 세션입니다
+type Period struct {
+	Name  string    `json:"name"`
+	Board string    `json:"board"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func (s Session) on(day time.Time) Period {
+	return Period{Name: s.Name, Board: s.Board, Start: day.Add(s.Start), End: day.Add(s.End)}
+}
+
+// Calendar 는 주말과 휴장일을 뺀 거래일을 계산합니다
+type Calendar struct {
+	holidays map[string]string // YYYYMMDD -> 이름
+}
+
+// New 는 휴장일(YYYYMMDD -> 이름)로 달력을 만듭니다 (nil 이면 주말만 휴장)
+func New(holidays map[string]string) *Calendar {
+	if holidays == nil {
+		holidays = map[string]string{}
+	}
+	return &Calendar{holidays: holidays}
+}
+
+// Load 는 휴장일 파일을 읽습니다
+// 한 줄에 하나씩 "YYYYMMDD 이름" 형식이며, 빈 줄과 # 로 시작하는 줄은 무시합니다
+func Load(path string) (*Calendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	holidays := map[string]string{}
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		date, name, _ := strings.Cut(line, " ")
+		if _, err := time.ParseInLocation("20060102", date, KST); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid date %q", path, n, date)
+		}
+		holidays[date] = strings.TrimSpace(name)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return New(holidays), nil
+}
+
+// Day 는 t 가 속한 날의 한국 시간 0시입니다
+func Day(t time.Time) time.Time {
+	y, m, d := t.In(KST).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, KST)
+}
+
+// Holiday 는 t 가 속한 날이 휴장일이면 이름을 반환합니다
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	name, ok := c.holidays[Day(t).Format("20060102")]
+	return name, ok
+}
+
+// IsTradingDay 는 t 가 속한 날이 거래일인지 확인합니다
+func (c *Calendar) IsTradingDay(t time.Time) bool {
+	switch Day(t).Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	_, holiday := c.Holiday(t)
+	return !holiday
+}
+
+// SessionAt 은 t 에 진행 중인 세션을 반환합니다 (장이 열려 있지 않으면 false)
+func (c *Calendar) SessionAt(t time.Time) (Period, bool) {
+	if !c.IsTradingDay(t) {
+		return Period{}, false
+	}
+	day := Day(t)
+	for _, s := range Sessions {
+		p := s.on(day)
+		if !t.Before(p.Start) && t.Before(p.End) {
+			return p, true
+		}
+	}
+	return Period{}, false
+}
+
+// IsOpen 은 t 에 매매 세션이 진행 중인지 확인합니다
+func (c *Calendar) IsOpen(t time.Time) bool {
+	_, ok := c.SessionAt(t)
+	return ok
+}
+
+// 1년 넘게 거래일이 없으면 휴장일 파일이 잘못된 것이므로 찾지 않음
+const maxSearchDays = 366
+
+// NextSession 은 t 이후에 시작하는 첫 세션을 반환합니다
+func (c *Calendar) NextSession(t time.Time) (Period, bool) {
+	day := Day(t)
+	for i := 0; i < maxSearchDays; i++ {
+		if c.IsTradingDay(day) {
+			for _, s := range Sessions {
+				if p := s.on(day); p.Start.After(t) {
+					return p, true
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return Period{}, false
+}
+
+// PreviousTradingDay 는 t 가 속한 날 이전의 마지막 거래일(한국 시간 0시)을 반환합니다
+func (c *Calendar) PreviousTradingDay(t time.Time) (time.Time, bool) {
+	day := Day(t)
+	for i := 0; i < maxSearchDays; i++ {
+		day = day.AddDate(0, 0, -1)
+		if c.IsTradingDay(day) {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// LatestTradingDay 는 t 시점에 가장 최근 데이터가 있는 거래일을 반환합니다
+// 오늘이 거래일이고 첫 세션이 시작되었으면 오늘, 아니면 직전 거래일입니다
+func (c *Calendar) LatestTradingDay(t time.Time) (time.Time, bool) {
+	if c.IsTradingDay(t) && !t.Before(Day(t).Add(Sessions[0].Start)) {
+		return Day(t), true
+	}
+	return c.PreviousTradingDay(t)
+}
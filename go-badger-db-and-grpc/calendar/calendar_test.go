@@ -0,0 +1,92 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func kst(s string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04", s, KST)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestSessions(t *testing.T) {
+	c := New(map[string]string{"20250505": "어린이날", "20250506": "대체공휴일"})
+
+	for at, want := range map[string]string{
+		"2025-04-29 08:29": "",
+		"2025-04-29 08:45": "pre-open",
+		"2025-04-29 10:00": "regular",
+		"2025-04-29 15:25": "closing-auction",
+		"2025-04-29 15:35": "", // 종가 동시호가와 장후 시간외 사이
+		"2025-04-29 15:50": "after-hours-close",
+		"2025-04-29 17:00": "after-hours-single",
+		"2025-04-29 18:00": "",
+		"2025-05-03 10:00": "", // 토요일
+		"2025-05-05 10:00": "", // 휴장일
+	} {
+		p, ok := c.SessionAt(kst(at))
+		if p.Name != want || ok != (want != "") || c.IsOpen(kst(at)) != ok {
+			t.Errorf("%s: session %q (%t), want %q", at, p.Name, ok, want)
+		}
+	}
+
+	for at, want := range map[string]string{
+		"2025-04-29 10:00": "2025-04-29 15:20 closing-auction",
+		"2025-05-02 18:30": "2025-05-07 08:30 pre-open", // 주말과 연휴 건너뜀
+	} {
+		p, ok := c.NextSession(kst(at))
+		if got := p.Start.Format("2006-01-02 15:04 ") + p.Name; !ok || got != want {
+			t.Errorf("next session after %s = %s, want %s", at, got, want)
+		}
+	}
+
+	for at, want := range map[string]string{
+		"2025-05-07 12:00": "20250502",
+		"2025-04-28 12:00": "20250425", // 월요일
+	} {
+		if d, ok := c.PreviousTradingDay(kst(at)); !ok || d.Format("20060102") != want {
+			t.Errorf("previous trading day of %s = %s, want %s", at, d.Format("20060102"), want)
+		}
+	}
+
+	for at, want := range map[string]string{
+		"2025-05-07 08:00": "20250502", // 첫 세션 전
+		"2025-05-07 08:30": "20250507",
+		"2025-05-04 12:00": "20250502", // 일요일
+	} {
+		if d, ok := c.LatestTradingDay(kst(at)); !ok || d.Format("20060102") != want {
+			t.Errorf("latest trading day at %s = %s, want %s", at, d.Format("20060102"), want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.txt")
+	os.WriteFile(path, []byte("# 주석\n\n20250101 신정\n20251225\n"), 0o644)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, ok := c.Holiday(kst("2025-01-01 10:00")); !ok || name != "신정" {
+		t.Errorf("holiday = %q, %t", name, ok)
+	}
+	if c.IsTradingDay(kst("2025-12-25 10:00")) || !c.IsTradingDay(kst("2025-12-24 10:00")) {
+		t.Error("20251225 should be the only holiday in the week")
+	}
+
+	os.WriteFile(path, []byte("2025-01-01 신정\n"), 0o644)
+	if _, err := Load(path); err == nil {
+		t.Error("invalid date: no error")
+	}
+
+	// 저장소의 휴장일 파일
+	if _, err := Load("../holidays.txt"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+// 시계열 키 형식 (tick:<ISIN>:<unix 나노초 8바이트 big-endian>), 같은 종목의 체결이 시간 순서로 정렬됩니다
+const tickKeyPrefix = "tick:"
+
+// 한 번에 만들 수 있는 최대 캔들 수
+const maxCandles = 10000
+
+// 캔들 간격
+var candleIntervals = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": 5 * time.Minute,
+	"1d": 24 * time.Hour,
+}
+
+// 종목 스냅샷에서 뽑은 체결 시점의 값
+type tick struct {
+	Price       int64 `json:"p"`
+	AccumVolume int64 `json:"v"` // 당일 누적 거래량
+	Halt        bool  `json:"h"` // 거래 정지 중
+}
+
+// 시계열 저장에 필요한 스냅샷 필드
+type stockSnapshot struct {
+	Code        string `json:"code"`
+	BaseDate    string `json:"baseDate"`
+	TradeTime   string `json:"tradeTime"`
+	Close       int64  `json:"close"`
+	AccumVolume int64  `json:"totalAccumQuantity"`
+	TradingHalt bool   `json:"tradingHalt"`
+}
+
+func tickKey(isin string, t time.Time) []byte {
+	key := append([]byte(tickKeyPrefix+isin+":"), make([]byte, 8)...)
+	binary.BigEndian.PutUint64(key[len(key)-8:], uint64(t.UnixNano()))
+	return key
+}
+
+func tickTime(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[len(key)-8:])))
+}
+
+// 종목 스냅샷이면 체결 시각(baseDate + tradeTime, 한국 시간)의 값을 시계열에 함께 저장합니다
+// 필요한 필드가 없는 문서는 스냅샷이 아니므로 무시합니다
+func recordTick(txn *badger.Txn, key, val []byte) error {
+	if !strings.HasPrefix(string(key), stockKeyPrefix) {
+		return nil
+	}
+	var s stockSnapshot
+	if err := json.Unmarshal(val, &s); err != nil || s.Code == "" || s.BaseDate == "" || s.TradeTime == "" {
+		return nil
+	}
+	t, err := time.ParseInLocation("20060102 15:04:05.999999999", s.BaseDate+" "+s.TradeTime, seoul)
+	if err != nil {
+		return nil
+	}
+	return saveTick(txn, s.Code, t, tick{Price: s.Close, AccumVolume: s.AccumVolume, Halt: s.TradingHalt})
+}
+
+// 체결 시점의 값을 시계열에 저장합니다
+func saveTick(txn *badger.Txn, isin string, t time.Time, s tick) error {
+	sample, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return txn.SetEntry(newEntry(tickKey(isin, t), encodeValue(valueFormat, sample)))
+}
+
+// Candle 은 간격 하나의 시가/고가/저가/종가와 거래량입니다
+// 체결이 없는 간격은 직전 종가로 채우고(거래량 0), 거래 정지 중이었던 간격은 Halted 로 표시합니다
+type Candle struct {
+	Time   time.Time `json:"time"` // 간격 시작 시각
+	Open   int64     `json:"open"`
+	High   int64     `json:"high"`
+	Low    int64     `json:"low"`
+	Close  int64     `json:"close"`
+	Volume int64     `json:"volume"`
+	Halted bool      `json:"halted"`
+}
+
+// 간격의 시작 시각 (일봉은 한국 시간 0시)
+func bucketStart(t time.Time, interval time.Duration) time.Time {
+	t = t.In(seoul)
+	if interval >= 24*time.Hour {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, seoul)
+	}
+	return t.Truncate(interval)
+}
+
+func sameDay(a, b time.Time) bool {
+	return bucketStart(a, 24*time.Hour).Equal(bucketStart(b, 24*time.Hour))
+}
+
+// [from, to) 구간의 체결로 캔들을 만듭니다
+// 거래량은 누적 거래량의 차이로 계산하므로, from 이 속한 날의 처음부터 읽습니다
+func loadCandles(isin string, from, to time.Time, interval time.Duration) ([]Candle, error) {
+	candles := []Candle{}
+	var (
+		cur      *Candle
+		prevTime time.Time
+		prev     tick
+		hasPrev  bool
+		last     int64 // 직전 종가 (정지 중의 값 제외)
+		traded   bool  // cur 에 정지 중이 아닌 체결이 있음
+	)
+	// 두 체결 사이에 체결이 없던 간격을 직전 종가로 채웁니다 (같은 날 안에서만, 마지막 체결 뒤는 채우지 않음)
+	fill := func(until time.Time) {
+		if !hasPrev || interval >= 24*time.Hour {
+			return
+		}
+		for b := bucketStart(prevTime, interval).Add(interval); b.Before(until) && sameDay(b, prevTime); b = b.Add(interval) {
+			if b.Before(from) {
+				continue
+			}
+			candles = append(candles, Candle{Time: b, Open: last, High: last, Low: last, Close: last, Halted: prev.Halt})
+		}
+	}
+
+	prefix := []byte(tickKeyPrefix + isin + ":")
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{Prefix: prefix, PrefetchValues: true, PrefetchSize: 100})
+		defer it.Close()
+		for it.Seek(tickKey(isin, bucketStart(from, 24*time.Hour))); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			t := tickTime(item.Key())
+			if !t.Before(to) {
+				break
+			}
+			var s tick
+			if err := item.Value(func(val []byte) error {
+				raw, err := decodeValue(val)
+				if err != nil {
+					return err
+				}
+				return json.Unmarshal(raw, &s)
+			}); err != nil {
+				return fmt.Errorf("%s: %w", item.Key(), err)
+			}
+
+			// 날이 바뀌면 누적 거래량을 처음부터 셈
+			volume := s.AccumVolume
+			if hasPrev && sameDay(prevTime, t) && s.AccumVolume >= prev.AccumVolume {
+				volume -= prev.AccumVolume
+			}
+
+			if !t.Before(from) {
+				b := bucketStart(t, interval)
+				if cur == nil || !cur.Time.Equal(b) {
+					fill(b)
+					// 체결이 나오기 전까지는 직전 종가로 둠
+					candles = append(candles, Candle{Time: b, Open: last, High: last, Low: last, Close: last})
+					cur, traded = &candles[len(candles)-1], false
+				}
+				cur.Volume += volume
+				if s.Halt {
+					// 정지 중의 값은 체결이 아니므로 가격에 반영하지 않음
+					cur.Halted = true
+				} else if !traded {
+					cur.Open, cur.High, cur.Low, cur.Close = s.Price, s.Price, s.Price, s.Price
+					traded = true
+				} else {
+					cur.High = max(cur.High, s.Price)
+					cur.Low = min(cur.Low, s.Price)
+					cur.Close = s.Price
+				}
+			}
+			prevTime, prev, hasPrev = t, s, true
+			if !s.Halt {
+				last = s.Price
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return candles, nil
+}
+
+// 종목의 기업 행위로 캔들의 가격과 거래량을 수정합니다
+func adjustCandles(isin string, candles []Candle) error {
+	adj, err := loadAdjuster(isin)
+	if err != nil {
+		return err
+	}
+	adj.candles(candles)
+	return nil
+}
+
+// 조회 구간 값을 읽습니다 (RFC3339 또는 한국 시간 날짜 YYYYMMDD, 날짜인 to 는 그날 끝까지 포함)
+func parseCandleTime(s string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("20060102", s, seoul)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (RFC3339 or YYYYMMDD)", s)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// 캔들 조회 파라미터를 확인합니다 (to 를 비우면 지금, from 을 비우면 to 가 속한 날의 0시, interval 기본 1m)
+func parseCandleQuery(fromStr, toStr, intervalStr string) (from, to time.Time, interval time.Duration, err error) {
+	if intervalStr == "" {
+		intervalStr = "1m"
+	}
+	interval, ok := candleIntervals[intervalStr]
+	if !ok {
+		return from, to, 0, fmt.Errorf("invalid interval %q (1m, 5m, 1d)", intervalStr)
+	}
+	to = time.Now()
+	if toStr != "" {
+		if to, err = parseCandleTime(toStr, true); err != nil {
+			return
+		}
+	}
+	from = bucketStart(to.Add(-time.Nanosecond), 24*time.Hour)
+	if fromStr != "" {
+		if from, err = parseCandleTime(fromStr, false); err != nil {
+			return
+		}
+	}
+	switch {
+	case !from.Before(to):
+		err = errors.New("from must be before to")
+	case to.Sub(from)/interval > maxCandles:
+		err = fmt.Errorf("too many candles (max %d)", maxCandles)
+	}
+	return
+}
+
+// /candles/{isin}?from=20250429&to=20250429&interval=5m : 체결 시계열로 만든 캔들
+func candlesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+	from, to, interval, err := parseCandleQuery(q.Get("from"), q.Get("to"), q.Get("interval"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	candles, err := loadCandles(r.PathValue("isin"), from, to, interval)
+	if err == nil && adjustedParam(r) {
+		err = adjustCandles(r.PathValue("isin"), candles)
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to load candles"})
+		return
+	}
+	json.NewEncoder(w).Encode(candles)
+}
+
+func (s *stockServer) GetCandles(ctx context.Context, req *pb.CandlesRequest) (*pb.Candles, error) {
+	from, to, interval, err := parseCandleQuery(req.From, req.To, req.Interval)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	candles, err := loadCandles(req.Isin, from, to, interval)
+	if err == nil && req.Adjusted {
+		err = adjustCandles(req.Isin, candles)
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.Candles{Isin: req.Isin, Interval: req.Interval, Candles: make([]*pb.Candle, len(candles))}
+	if resp.Interval == "" {
+		resp.Interval = "1m"
+	}
+	for i, c := range candles {
+		resp.Candles[i] = &pb.Candle{
+			Time:   c.Time.Format(time.RFC3339),
+			Open:   c.Open,
+			High:   c.High,
+			Low:    c.Low,
+			Close:  c.Close,
+			Volume: c.Volume,
+			Halted: c.Halted,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// 종목 스냅샷을 저장합니다 (putValue 가 체결 시계열도 함께 저장)
+func putSnapshot(t *testing.T, date, tradeTime string, price, accum int64, halt bool) {
+	t.Helper()
+	doc := fmt.Sprintf(`{"code":"KR7005930003","baseDate":%q,"tradeTime":%q,"close":%d,"totalAccumQuantity":%d,"tradingHalt":%t}`,
+		date, tradeTime, price, accum, halt)
+	err := db.Update(func(txn *badger.Txn) error {
+		return putValue(txn, []byte("stock:"+date+":KR7005930003"), []byte(doc))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func candlesFor(t *testing.T, from, to, interval string) []Candle {
+	t.Helper()
+	f, tt, iv, err := parseCandleQuery(from, to, interval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	candles, err := loadCandles("KR7005930003", f, tt, iv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return candles
+}
+
+func TestCandles(t *testing.T) {
+	openTestDB(t)
+	putSnapshot(t, "20250429", "09:00:10.1", 100, 10, false)
+	putSnapshot(t, "20250429", "09:00:40.2", 105, 30, false)
+	putSnapshot(t, "20250429", "09:01:20.3", 98, 35, false)
+	// 거래 정지 (정지 중의 값은 간격의 첫 값이어도 가격에 반영하지 않음)
+	putSnapshot(t, "20250429", "09:03:05.4", 90, 35, true)
+	putSnapshot(t, "20250429", "09:05:10.0", 90, 35, true)
+	putSnapshot(t, "20250429", "09:05:30.5", 110, 60, false)
+	putSnapshot(t, "20250430", "09:00:00.0", 111, 5, false) // 다음 날 (누적 거래량 초기화)
+
+	type ohlcv struct {
+		at            string
+		o, h, l, c, v int64
+		halted        bool
+	}
+	check := func(name string, got []Candle, want []ohlcv) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: %d candles, want %d: %+v", name, len(got), len(want), got)
+		}
+		for i, w := range want {
+			g := got[i]
+			if g.Time.In(seoul).Format("0102 15:04") != w.at || g.Open != w.o || g.High != w.h || g.Low != w.l || g.Close != w.c || g.Volume != w.v || g.Halted != w.halted {
+				t.Errorf("%s[%d] = %s %+v, want %+v", name, i, g.Time.In(seoul).Format("0102 15:04"), g, w)
+			}
+		}
+	}
+
+	// 체결이 없던 09:02 는 직전 종가로, 정지 중이던 09:03~09:04 는 halted 로 채움
+	check("1m", candlesFor(t, "20250429", "20250429", "1m"), []ohlcv{
+		{"0429 09:00", 100, 105, 100, 105, 30, false},
+		{"0429 09:01", 98, 98, 98, 98, 5, false},
+		{"0429 09:02", 98, 98, 98, 98, 0, false},
+		{"0429 09:03", 98, 98, 98, 98, 0, true},
+		{"0429 09:04", 98, 98, 98, 98, 0, true},
+		{"0429 09:05", 110, 110, 110, 110, 25, true},
+	})
+	check("5m", candlesFor(t, "20250429", "20250429", "5m"), []ohlcv{
+		{"0429 09:00", 100, 105, 98, 98, 35, true},
+		{"0429 09:05", 110, 110, 110, 110, 25, true},
+	})
+	check("1d", candlesFor(t, "20250429", "20250430", "1d"), []ohlcv{
+		{"0429 00:00", 100, 110, 98, 110, 60, true},
+		{"0430 00:00", 111, 111, 111, 111, 5, false},
+	})
+
+	// 하루 중간부터 조회해도 거래량은 직전 체결과의 차이
+	check("range", candlesFor(t, "2025-04-29T09:01:00+09:00", "2025-04-29T09:04:00+09:00", "1m"), []ohlcv{
+		{"0429 09:01", 98, 98, 98, 98, 5, false},
+		{"0429 09:02", 98, 98, 98, 98, 0, false},
+		{"0429 09:03", 98, 98, 98, 98, 0, true},
+	})
+}
+
+func TestCandleQueryValidation(t *testing.T) {
+	for _, q := range [][3]string{
+		{"20250429", "20250429", "3m"},   // 지원하지 않는 간격
+		{"20250430", "20250429", "1m"},   // from 이 to 보다 늦음
+		{"20240101", "20250429", "1m"},   // 캔들 수 초과
+		{"2025-04-29", "20250429", "1m"}, // 날짜 형식 오류
+	} {
+		if _, _, _, err := parseCandleQuery(q[0], q[1], q[2]); err == nil {
+			t.Errorf("%v: no error", q)
+		}
+	}
+	if _, _, iv, err := parseCandleQuery("", "", ""); err != nil || iv != time.Minute {
+		t.Errorf("defaults: %v, %v", iv, err)
+	}
+
+	openTestDB(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/candles/{isin}", candlesHandler)
+	for path, want := range map[string]int{
+		"/candles/KR7005930003?from=20250429&to=20250429&interval=5m": http.StatusOK,
+		"/candles/KR7005930003?interval=3m":                           http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status %d, want %d (%s)", path, rec.Code, want, rec.Body)
+		}
+	}
+}
@@ -0,0 +1,493 @@
+// replay 는 기록한 시세 피드(줄 단위 JSON 체결/호가)를 서버에 다시 흘려 장중 상태를 재현합니다
+//
+//	go run ./cmd/replay -file day.jsonl                       # POST /ingest 로 1배속 재생
+//	go run ./cmd/replay -file day.jsonl -speed 10             # 10배속
+//	go run ./cmd/replay -file day.jsonl -speed 0 -expect expected.json
+//	go run ./cmd/replay -file day.jsonl -mode tcp -listen :9000  # 서버를 -feed replay=tcp://localhost:9000 로 실행
+//
+// 메시지는 기록한 줄을 그대로 보내므로 이벤트 시각(date, time)과 순번이 유지되고,
+// 재생 중에는 -control 주소의 /pause, /resume, /status 로 멈추거나 다시 시작할 수 있습니다
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/yiminan/go-examples/go-badger-db-and-grpc/calendar"
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+// 한 번에 보낼 최대 메시지 수 (같은 시각에 보낼 메시지를 모음)
+const maxChunk = 500
+
+// 기록한 메시지 한 줄
+type record struct {
+	line []byte
+	at   time.Time // 이벤트 시각 (읽지 못하면 직전 메시지의 시각)
+}
+
+// 기록 파일을 읽습니다
+// 시각을 읽을 수 없는 줄도 서버가 같은 에러를 내도록 그대로 보내며, 직전 메시지와 같은 시각에 보냅니다
+func loadRecording(r io.Reader) ([]record, error) {
+	var records []record
+	var last time.Time
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var m struct {
+			Date string `json:"date"`
+			Time string `json:"time"`
+		}
+		if json.Unmarshal(line, &m) == nil {
+			if t, err := time.ParseInLocation("20060102 15:04:05.999999999", m.Date+" "+m.Time, calendar.KST); err == nil {
+				last = t
+			}
+		}
+		records = append(records, record{line: append([]byte(nil), line...), at: last})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && records[0].at.IsZero() {
+		// 앞쪽의 시각 없는 줄은 첫 시각에 맞춤
+		for i := range records {
+			if !records[i].at.IsZero() {
+				for j := 0; j < i; j++ {
+					records[j].at = records[i].at
+				}
+				break
+			}
+		}
+	}
+	return records, nil
+}
+
+// player 는 기록을 이벤트 시각 간격에 맞춰 보내며, 재생 중 멈추고 다시 시작할 수 있습니다
+type player struct {
+	speed float64 // 배속 (0 이면 기다리지 않음)
+	now   func() time.Time
+
+	mu       sync.Mutex
+	start    time.Time     // 첫 메시지를 보낸 시각 (멈춘 시간만큼 뒤로 미룸)
+	resume   chan struct{} // 멈춘 동안만 있고, 다시 시작하면 닫힘
+	pausedAt time.Time
+	sent     int
+	total    int
+	last     time.Time // 마지막으로 보낸 메시지의 이벤트 시각
+}
+
+func newPlayer(speed float64) *player {
+	return &player{speed: speed, now: time.Now}
+}
+
+// 재생을 멈춥니다 (이미 멈췄으면 그대로)
+func (p *player) pause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.resume == nil {
+		p.resume = make(chan struct{})
+		p.pausedAt = p.now()
+	}
+}
+
+// 멈춘 재생을 다시 시작합니다 (남은 메시지는 멈춘 시간만큼 늦게 보냄)
+func (p *player) unpause() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.resume != nil {
+		close(p.resume)
+		p.resume = nil
+		p.start = p.start.Add(p.now().Sub(p.pausedAt))
+	}
+}
+
+// ReplayStatus 는 재생 진행 상태입니다 (/status)
+type ReplayStatus struct {
+	Paused    bool      `json:"paused"`
+	Speed     float64   `json:"speed"`
+	Sent      int       `json:"sent"`
+	Total     int       `json:"total"`
+	EventTime time.Time `json:"eventTime"` // 마지막으로 보낸 메시지의 이벤트 시각
+}
+
+func (p *player) status() ReplayStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return ReplayStatus{Paused: p.resume != nil, Speed: p.speed, Sent: p.sent, Total: p.total, EventTime: p.last}
+}
+
+// offset 만큼 재생 시간이 지날 때까지 기다립니다 (멈춘 동안은 다시 시작할 때까지)
+func (p *player) wait(ctx context.Context, offset time.Duration) error {
+	for {
+		p.mu.Lock()
+		resume, due := p.resume, p.start.Add(offset)
+		p.mu.Unlock()
+
+		if resume != nil {
+			select {
+			case <-resume:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		d := due.Sub(p.now())
+		if p.speed == 0 || d <= 0 {
+			return nil
+		}
+		// 기다리는 중에 멈추면 바로 알 수 있도록 짧게 나눠 기다림
+		select {
+		case <-time.After(min(d, 50*time.Millisecond)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// 첫 메시지부터의 재생 시간
+func (p *player) offset(first, at time.Time) time.Duration {
+	if p.speed == 0 {
+		return 0
+	}
+	return time.Duration(float64(at.Sub(first)) / p.speed)
+}
+
+// 기록을 순서대로 send 로 보냅니다 (보낼 시각이 된 메시지는 최대 maxChunk 개씩 모아 보냄)
+func (p *player) run(ctx context.Context, records []record, send func([][]byte) error) error {
+	p.mu.Lock()
+	p.start, p.total = p.now(), len(records)
+	p.mu.Unlock()
+	if len(records) == 0 {
+		return nil
+	}
+
+	first := records[0].at
+	for i := 0; i < len(records); {
+		if err := p.wait(ctx, p.offset(first, records[i].at)); err != nil {
+			return err
+		}
+		p.mu.Lock()
+		due := p.now().Sub(p.start)
+		p.mu.Unlock()
+		j := i + 1
+		for j < len(records) && j-i < maxChunk && p.offset(first, records[j].at) <= due {
+			j++
+		}
+
+		lines := make([][]byte, 0, j-i)
+		for _, r := range records[i:j] {
+			lines = append(lines, r.line)
+		}
+		if err := send(lines); err != nil {
+			return err
+		}
+		p.mu.Lock()
+		p.sent, p.last = j, records[j-1].at
+		p.mu.Unlock()
+		i = j
+	}
+	return nil
+}
+
+// 제어 엔드포인트 (/pause, /resume: POST, /status: GET)
+func (p *player) handler() http.Handler {
+	mux := http.NewServeMux()
+	control := func(fn func()) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				http.Error(w, "Only POST method allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			fn()
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(p.status())
+		}
+	}
+	mux.HandleFunc("/pause", control(p.pause))
+	mux.HandleFunc("/resume", control(p.unpause))
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(p.status())
+	})
+	return mux
+}
+
+// POST /ingest?source=<name> 로 보냅니다
+func httpSender(server, source string) func([][]byte) error {
+	url := strings.TrimSuffix(server, "/") + "/ingest?source=" + source
+	return func(lines [][]byte) error {
+		body := append(bytes.Join(lines, []byte("\n")), '\n')
+		resp, err := http.Post(url, "application/x-ndjson", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted {
+			msg, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf("POST %s: %s %s", url, resp.Status, bytes.TrimSpace(msg))
+		}
+		return nil
+	}
+}
+
+// 서버의 TCP 피드 소스가 접속하면 그 연결로 보냅니다
+func tcpSender(ctx context.Context, addr string) (func([][]byte) error, func(), error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer lis.Close()
+	log.Printf("waiting for the server feed source to connect to %s", lis.Addr())
+	stop := context.AfterFunc(ctx, func() { lis.Close() })
+	defer stop()
+	conn, err := lis.Accept()
+	if err != nil {
+		return nil, nil, err
+	}
+	w := bufio.NewWriter(conn)
+	send := func(lines [][]byte) error {
+		for _, line := range lines {
+			w.Write(line)
+			w.WriteByte('\n')
+		}
+		return w.Flush()
+	}
+	return send, func() { conn.Close() }, nil
+}
+
+// 서버가 소스에서 처리를 마친 메시지 수 (/ingest/stats 의 processed, 소스가 없으면 0)
+func processed(server, source string) (int64, error) {
+	resp, err := http.Get(strings.TrimSuffix(server, "/") + "/ingest/stats")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	var stats map[string]struct {
+		Processed int64 `json:"processed"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return 0, err
+	}
+	return stats[source].Processed, nil
+}
+
+// 서버가 n 개를 처리할 때까지 기다립니다
+func waitProcessed(server, source string, n int64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		got, err := processed(server, source)
+		if err == nil && got >= n {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("server processed %d of %d messages in %s", got, n, timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// JSON 을 숫자 표기와 상관없이 비교할 수 있게 읽습니다 (숫자는 float64)
+func decodeJSON(data []byte) (interface{}, error) {
+	var v interface{}
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// 기대 값에 있는 필드만 비교해 다른 곳을 반환합니다 (객체는 필드별로, 배열과 값은 전체를 비교)
+func diffJSON(path string, want, got interface{}) []string {
+	wm, ok := want.(map[string]interface{})
+	if !ok {
+		if !reflect.DeepEqual(want, got) {
+			w, _ := json.Marshal(want)
+			g, _ := json.Marshal(got)
+			return []string{fmt.Sprintf("%s: expected %s, got %s", path, w, g)}
+		}
+		return nil
+	}
+	gm, ok := got.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: expected an object", path)}
+	}
+	keys := make([]string, 0, len(wm))
+	for k := range wm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var diffs []string
+	for _, k := range keys {
+		g, ok := gm[k]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s.%s: missing", path, k))
+			continue
+		}
+		diffs = append(diffs, diffJSON(path+"."+k, wm[k], g)...)
+	}
+	return diffs
+}
+
+// 기대 스냅샷 파일({"<키>": {문서}})의 키마다 GetStockMaster 결과를 비교합니다
+func verify(ctx context.Context, client pb.StockServiceClient, expected map[string]json.RawMessage) ([]string, error) {
+	keys := make([]string, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var diffs []string
+	for _, key := range keys {
+		want, err := decodeJSON(expected[key])
+		if err != nil {
+			return nil, fmt.Errorf("expected %s: %w", key, err)
+		}
+		resp, err := client.GetStockMaster(ctx, &pb.StockRequest{Key: key})
+		if err != nil {
+			return nil, fmt.Errorf("GetStockMaster %s: %w", key, err)
+		}
+		got, err := decodeJSON([]byte(resp.Value))
+		if err != nil {
+			diffs = append(diffs, fmt.Sprintf("%s: %s", key, resp.Value))
+			continue
+		}
+		diffs = append(diffs, diffJSON(key, want, got)...)
+	}
+	return diffs, nil
+}
+
+// 실행 옵션 (명령행 플래그)
+type options struct {
+	file, mode, server, source, listen string
+	controlAddr, expect, grpcAddr      string
+	speed                              float64
+	timeout                            time.Duration
+}
+
+func main() {
+	var o options
+	flag.StringVar(&o.file, "file", "", "기록한 피드 파일 (줄 단위 JSON)")
+	flag.StringVar(&o.mode, "mode", "http", "보내는 방식: http (POST /ingest), tcp (서버의 TCP 피드 소스가 접속)")
+	flag.StringVar(&o.server, "server", "http://localhost:8081", "서버 HTTP 주소 (/ingest, /ingest/stats)")
+	flag.StringVar(&o.source, "source", "replay", "서버의 피드 소스 이름 (tcp 이면 서버 -feed 의 이름과 같아야 함)")
+	flag.StringVar(&o.listen, "listen", ":9000", "tcp 방식에서 서버의 피드 소스가 접속할 주소")
+	flag.Float64Var(&o.speed, "speed", 1, "배속 (1: 기록 시각 간격대로, 10: 10배속, 0: 기다리지 않음)")
+	flag.StringVar(&o.controlAddr, "control", ":8099", "재생 제어 주소 (/pause, /resume, /status, 비우면 사용 안 함)")
+	flag.StringVar(&o.expect, "expect", "", "재생 후 비교할 기대 스냅샷 파일 ({\"<키>\": {문서}}, 적은 필드만 비교)")
+	flag.StringVar(&o.grpcAddr, "grpc", "localhost:50051", "기대 스냅샷을 비교할 gRPC 서버 주소")
+	flag.DurationVar(&o.timeout, "timeout", 30*time.Second, "재생 후 서버가 메시지를 모두 저장할 때까지 기다릴 시간")
+	flag.Parse()
+
+	// 에러는 run 에서 반환해 연결 정리(defer)가 끝난 뒤 종료
+	if err := run(o); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(o options) error {
+	if o.file == "" {
+		return errors.New("-file is required")
+	}
+	if o.speed < 0 {
+		return errors.New("-speed must not be negative")
+	}
+	f, err := os.Open(o.file)
+	if err != nil {
+		return err
+	}
+	records, err := loadRecording(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	var expected map[string]json.RawMessage
+	if o.expect != "" {
+		data, err := os.ReadFile(o.expect)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &expected); err != nil {
+			return fmt.Errorf("%s: %w", o.expect, err)
+		}
+	}
+
+	ctx := context.Background()
+	p := newPlayer(o.speed)
+	if o.controlAddr != "" {
+		lis, err := net.Listen("tcp", o.controlAddr)
+		if err != nil {
+			return fmt.Errorf("control server failed: %w", err)
+		}
+		defer lis.Close()
+		go http.Serve(lis, p.handler())
+	}
+
+	// 서버가 이전에 같은 소스로 처리한 메시지 수 (재생한 메시지를 모두 처리했는지 확인할 때 사용)
+	base, err := processed(o.server, o.source)
+	if err != nil {
+		return err
+	}
+
+	var send func([][]byte) error
+	switch o.mode {
+	case "http":
+		send = httpSender(o.server, o.source)
+	case "tcp":
+		var closeConn func()
+		if send, closeConn, err = tcpSender(ctx, o.listen); err != nil {
+			return err
+		}
+		defer closeConn()
+	default:
+		return fmt.Errorf("unknown mode %q (http or tcp)", o.mode)
+	}
+
+	started := time.Now()
+	if err := p.run(ctx, records, send); err != nil {
+		return err
+	}
+	if err := waitProcessed(o.server, o.source, base+int64(len(records)), o.timeout); err != nil {
+		return err
+	}
+	fmt.Printf("%d개 메시지 재생 완료 (%s)\n", len(records), time.Since(started).Round(time.Millisecond))
+
+	if expected == nil {
+		return nil
+	}
+	conn, err := grpc.NewClient(o.grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	diffs, err := verify(ctx, pb.NewStockServiceClient(conn), expected)
+	if err != nil {
+		return err
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("스냅샷이 기대 값과 다릅니다:\n%s", strings.Join(diffs, "\n"))
+	}
+	fmt.Printf("스냅샷 %d개가 기대 값과 일치합니다\n", len(expected))
+	return nil
+}
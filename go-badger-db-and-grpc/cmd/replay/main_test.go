@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testRecording = `{"type":"trade","isin":"KR7005930003","date":"20250429","time":"09:00:00.0","seq":1,"price":49000,"volume":10}
+{"type":"trade","isin":"KR7005930003","date":"20250429","time":"09:00:00.0","seq":2,"price":49050,"volume":5}
+not json
+{"type":"trade","isin":"KR7005930003","date":"20250429","time":"09:00:02.0","seq":3,"price":49100,"volume":1}
+
+{"type":"trade","isin":"KR7005930003","date":"20250429","time":"09:00:04.0","seq":4,"price":49000,"volume":2}
+`
+
+func TestLoadRecording(t *testing.T) {
+	records, err := loadRecording(strings.NewReader(testRecording))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("records = %d, want 5", len(records))
+	}
+	// 시각이 없는 줄은 직전 메시지의 시각
+	if string(records[2].line) != "not json" || !records[2].at.Equal(records[1].at) {
+		t.Errorf("records[2] = %s at %s", records[2].line, records[2].at)
+	}
+	if d := records[4].at.Sub(records[0].at); d != 4*time.Second {
+		t.Errorf("span = %s, want 4s", d)
+	}
+}
+
+// 보낸 메시지 묶음과 보낸 시각을 기록합니다
+type recorder struct {
+	mu     sync.Mutex
+	chunks [][][]byte
+	times  []time.Time
+}
+
+func (r *recorder) send(lines [][]byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.chunks = append(r.chunks, lines)
+	r.times = append(r.times, time.Now())
+	return nil
+}
+
+func TestPlayerPacing(t *testing.T) {
+	records, _ := loadRecording(strings.NewReader(testRecording))
+
+	// 최대 속도는 기다리지 않고 한 번에 보냄
+	var fast recorder
+	if err := newPlayer(0).run(context.Background(), records, fast.send); err != nil {
+		t.Fatal(err)
+	}
+	if len(fast.chunks) != 1 || len(fast.chunks[0]) != 5 {
+		t.Errorf("max speed chunks = %d", len(fast.chunks))
+	}
+
+	// 20배속: 0초(3개), 2초(1개), 4초(1개) -> 0, 100ms, 200ms
+	var paced recorder
+	p := newPlayer(20)
+	start := time.Now()
+	if err := p.run(context.Background(), records, paced.send); err != nil {
+		t.Fatal(err)
+	}
+	if len(paced.chunks) != 3 || len(paced.chunks[0]) != 3 {
+		t.Fatalf("chunks = %d", len(paced.chunks))
+	}
+	if d := paced.times[2].Sub(start); d < 200*time.Millisecond || d > time.Second {
+		t.Errorf("last chunk sent after %s, want about 200ms", d)
+	}
+	if s := p.status(); s.Sent != 5 || s.Total != 5 || !s.EventTime.Equal(records[4].at) {
+		t.Errorf("status = %+v", s)
+	}
+}
+
+func TestPlayerPauseResume(t *testing.T) {
+	records, _ := loadRecording(strings.NewReader(testRecording))
+	var rec recorder
+	p := newPlayer(20)
+	p.pause()
+
+	done := make(chan error, 1)
+	go func() { done <- p.run(context.Background(), records, rec.send) }()
+
+	time.Sleep(300 * time.Millisecond)
+	if s := p.status(); !s.Paused || s.Sent != 0 {
+		t.Fatalf("paused status = %+v", s)
+	}
+	resumed := time.Now()
+	p.unpause()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	// 멈춘 시간은 재생 시간에 포함하지 않음
+	if d := rec.times[len(rec.times)-1].Sub(resumed); d < 150*time.Millisecond {
+		t.Errorf("last chunk sent %s after resume, want about 200ms", d)
+	}
+
+	// 멈춘 상태에서 취소하면 끝남
+	p = newPlayer(1)
+	p.pause()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.run(ctx, records, rec.send); err != context.Canceled {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	want, _ := decodeJSON([]byte(`{"close":49000,"volume":{"G1":17},"limitPrice":{"G1":{"sellPrice":[49050,49100]}}}`))
+	got, _ := decodeJSON([]byte(`{"close":49000.0,"open":48000,"volume":{"G1":17,"G3":1},"limitPrice":{"G1":{"sellPrice":[49050,49100],"dt":"x"}}}`))
+	if diffs := diffJSON("k", want, got); len(diffs) != 0 {
+		t.Errorf("diffs = %v", diffs)
+	}
+
+	got, _ = decodeJSON([]byte(`{"close":49100,"volume":{},"limitPrice":{"G1":{"sellPrice":[49050]}}}`))
+	diffs := diffJSON("k", want, got)
+	wantDiffs := []string{
+		"k.close: expected 49000, got 49100",
+		"k.limitPrice.G1.sellPrice: expected [49050,49100], got [49050]",
+		"k.volume.G1: missing",
+	}
+	if strings.Join(diffs, "\n") != strings.Join(wantDiffs, "\n") {
+		t.Errorf("diffs = %q", diffs)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// 기업 행위(권리락/배당락) 키 형식 (ca:<ISIN>:<YYYYMMDD>:<종류>)
+// 같은 날의 분할과 배당은 따로 저장하고, 같은 날 같은 종류를 다시 저장하면 정정으로 보고 덮어씁니다
+const corpActionKeyPrefix = "ca:"
+
+// CorporateAction 은 종목의 액면분할/병합, 현금 배당 한 건입니다
+// 효력일(EffectiveDate) 이전 거래일의 가격과 거래량을 효력일 이후 기준으로 수정할 때 사용합니다
+type CorporateAction struct {
+	ISIN          string  `json:"isin"`
+	EffectiveDate string  `json:"effectiveDate"`        // YYYYMMDD (권리락/배당락일)
+	SplitRatio    float64 `json:"splitRatio,omitempty"` // 1주당 새 주식 수 (50:1 분할은 50, 10:1 병합은 0.1)
+	Dividend      float64 `json:"dividend,omitempty"`   // 1주당 현금 배당
+	// 배당락 전일 종가 (비우면 체결 시계열에서 효력일 직전 종가를 찾음)
+	ReferencePrice float64 `json:"referencePrice,omitempty"`
+}
+
+func corpActionKey(a CorporateAction) []byte {
+	return []byte(corpActionKeyPrefix + a.ISIN + ":" + a.EffectiveDate + ":" + a.kind())
+}
+
+// 기업 행위 종류 (split, dividend, 한 건에 둘 다 있으면 split+dividend)
+func (a CorporateAction) kind() string {
+	switch {
+	case a.SplitRatio > 0 && a.Dividend > 0:
+		return "split+dividend"
+	case a.SplitRatio > 0:
+		return "split"
+	default:
+		return "dividend"
+	}
+}
+
+func (a CorporateAction) validate() error {
+	switch {
+	case a.ISIN == "":
+		return errors.New("missing isin")
+	case !dateRe.MatchString(a.EffectiveDate):
+		return fmt.Errorf("invalid effectiveDate %q (YYYYMMDD)", a.EffectiveDate)
+	case a.SplitRatio < 0 || a.Dividend < 0 || a.ReferencePrice < 0:
+		return errors.New("splitRatio, dividend and referencePrice must not be negative")
+	case a.SplitRatio == 0 && a.Dividend == 0:
+		return errors.New("splitRatio or dividend is required")
+	}
+	return nil
+}
+
+// 기업 행위 하나의 가격/거래량 수정 계수
+// 분할은 가격 1/비율, 거래량 비율, 배당은 가격 (기준가 - 배당) / 기준가 (기준가를 모르면 수정하지 않음)
+func (a CorporateAction) factors() (price, volume float64) {
+	price, volume = 1, 1
+	if a.SplitRatio > 0 {
+		price, volume = 1/a.SplitRatio, a.SplitRatio
+	}
+	if a.Dividend > 0 && a.ReferencePrice > a.Dividend {
+		price *= (a.ReferencePrice - a.Dividend) / a.ReferencePrice
+	}
+	return price, volume
+}
+
+func saveCorporateAction(a CorporateAction) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return db.Update(func(txn *badger.Txn) error {
+		return txn.SetEntry(newEntry(corpActionKey(a), encodeValue(valueFormat, data)))
+	})
+}
+
+// 종목의 기업 행위를 효력일 순서로 반환합니다
+func listCorporateActions(isin string) ([]CorporateAction, error) {
+	actions := []CorporateAction{}
+	prefix := []byte(corpActionKeyPrefix + isin + ":")
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{Prefix: prefix, PrefetchValues: true, PrefetchSize: 100})
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			var a CorporateAction
+			if err := it.Item().Value(func(val []byte) error {
+				raw, err := decodeValue(val)
+				if err != nil {
+					return err
+				}
+				return json.Unmarshal(raw, &a)
+			}); err != nil {
+				return fmt.Errorf("%s: %w", it.Item().Key(), err)
+			}
+			actions = append(actions, a)
+		}
+		return nil
+	})
+	return actions, err
+}
+
+// 효력일 직전(한국 시간 0시 이전) 마지막 체결가를 찾습니다 (없으면 0)
+func closeBefore(isin, date string) (float64, error) {
+	day, err := time.ParseInLocation("20060102", date, seoul)
+	if err != nil {
+		return 0, err
+	}
+	var price float64
+	prefix := []byte(tickKeyPrefix + isin + ":")
+	err = db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{Prefix: prefix, Reverse: true})
+		defer it.Close()
+		// 역방향 Seek 은 주어진 키 이하의 가장 큰 키로 이동
+		it.Seek(tickKey(isin, day.Add(-time.Nanosecond)))
+		for ; it.ValidForPrefix(prefix); it.Next() {
+			var s tick
+			if err := it.Item().Value(func(val []byte) error {
+				raw, err := decodeValue(val)
+				if err != nil {
+					return err
+				}
+				return json.Unmarshal(raw, &s)
+			}); err != nil {
+				return err
+			}
+			if !s.Halt {
+				price = float64(s.Price)
+				return nil
+			}
+		}
+		return nil
+	})
+	return price, err
+}
+
+// adjuster 는 종목의 기업 행위로 과거 가격과 거래량을 수정합니다
+type adjuster struct {
+	actions []CorporateAction // 효력일 순서
+}
+
+// 종목의 기업 행위를 읽고, 기준가가 없는 배당은 체결 시계열에서 기준가를 채웁니다
+func loadAdjuster(isin string) (*adjuster, error) {
+	actions, err := listCorporateActions(isin)
+	if err != nil {
+		return nil, err
+	}
+	for i, a := range actions {
+		if a.Dividend > 0 && a.ReferencePrice == 0 {
+			if actions[i].ReferencePrice, err = closeBefore(isin, a.EffectiveDate); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.Slice(actions, func(i, j int) bool { return actions[i].EffectiveDate < actions[j].EffectiveDate })
+	return &adjuster{actions: actions}, nil
+}
+
+// 거래일(YYYYMMDD)의 가격/거래량 수정 계수 (그날 이후에 효력이 생긴 기업 행위의 곱)
+func (a *adjuster) factors(date string) (price, volume float64) {
+	price, volume = 1, 1
+	for _, ca := range a.actions {
+		if ca.EffectiveDate > date {
+			p, v := ca.factors()
+			price *= p
+			volume *= v
+		}
+	}
+	return price, volume
+}
+
+// 원 단위로 반올림한 수정 가격
+func adjustPrice(price int64, factor float64) int64 {
+	return int64(math.Round(float64(price) * factor))
+}
+
+// 캔들의 가격과 거래량을 수정합니다
+func (a *adjuster) candles(candles []Candle) {
+	for i := range candles {
+		c := &candles[i]
+		p, v := a.factors(c.Time.In(seoul).Format("20060102"))
+		if p == 1 && v == 1 {
+			continue
+		}
+		c.Open, c.High, c.Low, c.Close = adjustPrice(c.Open, p), adjustPrice(c.High, p), adjustPrice(c.Low, p), adjustPrice(c.Close, p)
+		c.Volume = int64(math.Round(float64(c.Volume) * v))
+	}
+}
+
+// 종목 문서의 prevClose, base 를 문서 거래일(baseDate) 기준 수정 가격으로 바꾸고 수정 계수(adjustmentFactor)를 추가합니다
+// 수정할 기업 행위가 없으면 문서를 그대로 반환하며, 수정하면 필드 순서가 이름순으로 바뀝니다
+func adjustStockDocument(value string) (string, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		return "", err
+	}
+	var code, baseDate string
+	json.Unmarshal(doc["code"], &code)
+	json.Unmarshal(doc["baseDate"], &baseDate)
+	if code == "" || baseDate == "" {
+		return value, nil
+	}
+
+	adj, err := loadAdjuster(code)
+	if err != nil {
+		return "", err
+	}
+	factor, _ := adj.factors(baseDate)
+	if factor == 1 {
+		return value, nil
+	}
+	for _, field := range []string{"prevClose", "base"} {
+		var price int64
+		if err := json.Unmarshal(doc[field], &price); err != nil {
+			continue
+		}
+		doc[field], _ = json.Marshal(adjustPrice(price, factor))
+	}
+	doc["adjustmentFactor"], _ = json.Marshal(factor)
+	data, err := json.Marshal(doc)
+	return string(data), err
+}
+
+// adjusted 쿼리 파라미터 (true 이면 수정 가격)
+func adjustedParam(r *http.Request) bool {
+	adjusted, _ := strconv.ParseBool(r.URL.Query().Get("adjusted"))
+	return adjusted
+}
+
+// /corporate-actions : 기업 행위 저장 (POST), /corporate-actions/{isin} : 종목의 기업 행위 목록 (GET)
+func corpActionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var a CorporateAction
+	if err := json.NewDecoder(r.Body).Decode(&a); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if err := a.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := saveCorporateAction(a); err != nil {
+		http.Error(w, "Failed to store corporate action", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(a)
+}
+
+func corpActionListHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	actions, err := listCorporateActions(r.PathValue("isin"))
+	if err != nil {
+		http.Error(w, "Failed to load corporate actions", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(actions)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// 종가만 담은 종목 스냅샷을 저장합니다 (체결 시계열에 15:30 종가로 기록)
+func putClose(t *testing.T, isin, date string, price, accum int64) {
+	t.Helper()
+	doc := fmt.Sprintf(`{"code":%q,"baseDate":%q,"tradeTime":"15:30:00.0","close":%d,"totalAccumQuantity":%d}`, isin, date, price, accum)
+	err := db.Update(func(txn *badger.Txn) error {
+		return putValue(txn, []byte("stock:"+date+":"+isin), []byte(doc))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAdjustedCandles(t *testing.T) {
+	openTestDB(t)
+
+	// 50:1 분할
+	putClose(t, "SPLIT", "20250428", 2500000, 100)
+	putClose(t, "SPLIT", "20250429", 50000, 5000)
+	if err := saveCorporateAction(CorporateAction{ISIN: "SPLIT", EffectiveDate: "20250429", SplitRatio: 50}); err != nil {
+		t.Fatal(err)
+	}
+	// 배당락 (기준가는 체결 시계열의 전일 종가 10000)
+	putClose(t, "DIV", "20250429", 10000, 10)
+	putClose(t, "DIV", "20250430", 9100, 10)
+	if err := saveCorporateAction(CorporateAction{ISIN: "DIV", EffectiveDate: "20250430", Dividend: 1000}); err != nil {
+		t.Fatal(err)
+	}
+
+	from, to, interval, err := parseCandleQuery("20250428", "20250430", "1d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []struct {
+		isin   string
+		closes []int64
+		vols   []int64
+	}{
+		{"SPLIT", []int64{50000, 50000}, []int64{5000, 5000}},
+		{"DIV", []int64{9000, 9100}, []int64{10, 10}},
+	} {
+		candles, err := loadCandles(c.isin, from, to, interval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := adjustCandles(c.isin, candles); err != nil {
+			t.Fatal(err)
+		}
+		if len(candles) != len(c.closes) {
+			t.Fatalf("%s: candles = %+v", c.isin, candles)
+		}
+		for i, cd := range candles {
+			if cd.Close != c.closes[i] || cd.Volume != c.vols[i] {
+				t.Errorf("%s[%d] = close %d volume %d, want %d %d", c.isin, i, cd.Close, cd.Volume, c.closes[i], c.vols[i])
+			}
+		}
+	}
+}
+
+func TestCorporateActionsSameDate(t *testing.T) {
+	openTestDB(t)
+
+	// 같은 날의 2:1 분할과 배당은 둘 다 저장되고, 같은 종류를 다시 저장하면 정정으로 덮어씀
+	for _, a := range []CorporateAction{
+		{ISIN: "KR7005930003", EffectiveDate: "20250429", SplitRatio: 2},
+		{ISIN: "KR7005930003", EffectiveDate: "20250429", Dividend: 500, ReferencePrice: 10000},
+		{ISIN: "KR7005930003", EffectiveDate: "20250429", Dividend: 1000, ReferencePrice: 10000},
+	} {
+		if err := saveCorporateAction(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	actions, err := listCorporateActions("KR7005930003")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("actions = %+v", actions)
+	}
+
+	adj, err := loadAdjuster("KR7005930003")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price, volume := adj.factors("20250428"); price != 0.45 || volume != 2 {
+		t.Errorf("factors = %v, %v, want 0.45, 2", price, volume)
+	}
+}
+
+func TestAdjustedStockDocument(t *testing.T) {
+	openTestDB(t)
+	initData()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stocks/{key}", stockHandler)
+	mux.HandleFunc("/corporate-actions", corpActionsHandler)
+	mux.HandleFunc("/corporate-actions/{isin}", corpActionListHandler)
+	post := func(body string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/corporate-actions", bytes.NewBufferString(body)))
+		return rec.Code
+	}
+
+	for _, body := range []string{
+		`{"isin":"KR7005930003","effectiveDate":"2025-05-01","splitRatio":2}`,
+		`{"isin":"KR7005930003","effectiveDate":"20250501"}`,
+		`{"isin":"KR7005930003","effectiveDate":"20250501","splitRatio":-1}`,
+	} {
+		if code := post(body); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", body, code)
+		}
+	}
+	// 거래일(20250429) 이후의 2:1 분할과 그 이전의 분할 (이전 것은 적용하지 않음)
+	for _, body := range []string{
+		`{"isin":"KR7005930003","effectiveDate":"20250501","splitRatio":2}`,
+		`{"isin":"KR7005930003","effectiveDate":"20250101","splitRatio":10}`,
+	} {
+		if code := post(body); code != http.StatusOK {
+			t.Errorf("%s: status %d", body, code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/corporate-actions/KR7005930003", nil))
+	var actions []CorporateAction
+	if err := json.Unmarshal(rec.Body.Bytes(), &actions); err != nil || len(actions) != 2 || actions[0].EffectiveDate != "20250101" {
+		t.Errorf("actions = %s (%v)", rec.Body, err)
+	}
+
+	var doc struct {
+		PrevClose        int64    `json:"prevClose"`
+		Base             int64    `json:"base"`
+		AdjustmentFactor *float64 `json:"adjustmentFactor"`
+	}
+	for _, c := range []struct {
+		query  string
+		price  int64
+		factor bool
+		cc     string
+	}{
+		{"", 69200, false, "public, max-age=86400"},
+		{"?adjusted=true", 34600, true, "no-cache"},
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stocks/"+testStockKey+c.query, nil))
+		doc.AdjustmentFactor = nil
+		if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+			t.Fatal(err)
+		}
+		if doc.PrevClose != c.price || doc.Base != c.price || (doc.AdjustmentFactor != nil) != c.factor {
+			t.Errorf("%q: prevClose %d base %d factor %v", c.query, doc.PrevClose, doc.Base, doc.AdjustmentFactor)
+		}
+		if cc := rec.Header().Get("Cache-Control"); cc != c.cc {
+			t.Errorf("%q: Cache-Control = %q", c.query, cc)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+// 값을 저장한 시각 키 형식 (vtime:<키>)
+// 값과 같은 트랜잭션에 쓰므로 커밋 버전이 같고, 값과 함께 이전 버전이 보관됩니다
+const versionTimeKeyPrefix = "vtime:"
+
+// 변경 종류
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+var (
+	errVersionNotFound = errors.New("version not found")
+	errInvalidVersion  = errors.New("invalid version")
+)
+
+func versionTimeKey(key []byte) []byte {
+	return append([]byte(versionTimeKeyPrefix), key...)
+}
+
+// 이전 버전을 -keep-versions 개까지 보관하는 키인지 확인합니다 (종목 스냅샷과 그 저장 시각)
+// 체결, VI, 검증 기록 등은 자주 바뀌고 비교하지 않으므로 최신 버전만 남깁니다
+func keepsVersions(key []byte) bool {
+	return bytes.HasPrefix(key, []byte(stockKeyPrefix)) || bytes.HasPrefix(key, []byte(versionTimeKeyPrefix+stockKeyPrefix))
+}
+
+// 저장할 항목을 만듭니다 (이전 버전을 보관하지 않는 키는 압축할 때 이전 버전을 버리도록 표시)
+func newEntry(key, val []byte) *badger.Entry {
+	e := badger.NewEntry(key, val)
+	if !keepsVersions(key) {
+		e = e.WithDiscard()
+	}
+	return e
+}
+
+// 값을 쓰는 트랜잭션에 저장 시각을 기록합니다
+func recordVersionTime(txn *badger.Txn, key []byte) error {
+	return txn.SetEntry(newEntry(versionTimeKey(key), binary.BigEndian.AppendUint64(nil, uint64(time.Now().UnixNano()))))
+}
+
+// StockVersion 은 키에 보관된 값의 버전 하나입니다
+type StockVersion struct {
+	Version uint64     `json:"version"`        // Badger 커밋 버전
+	Time    *time.Time `json:"time,omitempty"` // 저장 시각 (저장 형식 변환 등으로 기록이 없으면 그 전 기록)
+	Deleted bool       `json:"deleted,omitempty"`
+	value   []byte     // 압축을 푼 값
+}
+
+// 키에 보관된 값의 버전을 최신부터 반환합니다 (-keep-versions 개까지, 압축 전에는 더 많을 수 있음)
+// stock: 이 아닌 키는 압축 뒤에는 최신 버전만 남습니다
+func listVersions(key string) ([]StockVersion, error) {
+	var versions []StockVersion
+	err := db.View(func(txn *badger.Txn) error {
+		type stamp struct {
+			version uint64
+			at      time.Time
+		}
+		var stamps []stamp // 최신부터
+		it := txn.NewKeyIterator(versionTimeKey([]byte(key)), badger.IteratorOptions{AllVersions: true})
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			if item.IsDeletedOrExpired() {
+				continue
+			}
+			if err := item.Value(func(val []byte) error {
+				if len(val) == 8 {
+					stamps = append(stamps, stamp{item.Version(), time.Unix(0, int64(binary.BigEndian.Uint64(val))).In(seoul)})
+				}
+				return nil
+			}); err != nil {
+				it.Close()
+				return err
+			}
+		}
+		it.Close()
+
+		it = txn.NewKeyIterator([]byte(key), badger.IteratorOptions{AllVersions: true, PrefetchValues: true, PrefetchSize: 10})
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			v := StockVersion{Version: item.Version(), Deleted: item.IsDeletedOrExpired()}
+			for _, s := range stamps {
+				if s.version <= v.Version {
+					at := s.at
+					v.Time = &at
+					break
+				}
+			}
+			if !v.Deleted {
+				if err := item.Value(func(val []byte) error {
+					raw, err := decodeValue(val)
+					v.value = raw
+					return err
+				}); err != nil {
+					return fmt.Errorf("version %d: %w", v.Version, err)
+				}
+			}
+			versions = append(versions, v)
+		}
+		return nil
+	})
+	return versions, err
+}
+
+// 버전 번호 또는 RFC3339 시각으로 버전을 고릅니다 (시각이면 그때 저장되어 있던 버전)
+// spec 을 비우면 def 번째 버전
+func selectVersion(versions []StockVersion, spec string, def int) (int, error) {
+	if spec == "" {
+		if def >= len(versions) {
+			return 0, fmt.Errorf("%w: no previous version", errVersionNotFound)
+		}
+		return def, nil
+	}
+	if n, err := strconv.ParseUint(spec, 10, 64); err == nil {
+		for i, v := range versions {
+			if v.Version == n {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("%w: version %d is not kept", errVersionNotFound, n)
+	}
+	at, err := time.Parse(time.RFC3339Nano, spec)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q (version number or RFC3339 time)", errInvalidVersion, spec)
+	}
+	for i, v := range versions {
+		if v.Time != nil && !v.Time.After(at) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: no version at %s", errVersionNotFound, spec)
+}
+
+// Change 는 필드 하나의 변경입니다
+type Change struct {
+	Path string          `json:"path"` // 예: close, limitPrice.G1.sellPrice[0] (빈 값이면 값 전체)
+	Op   string          `json:"op"`   // added, removed, changed
+	From json.RawMessage `json:"from,omitempty"`
+	To   json.RawMessage `json:"to,omitempty"`
+}
+
+// 값을 JSON 으로 읽습니다 (숫자는 원래 표기, JSON 이 아니면 문자열, 없으면 nil)
+func decodeVersionValue(v StockVersion) interface{} {
+	if v.Deleted {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(v.value))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil || dec.More() {
+		return string(v.value)
+	}
+	return doc
+}
+
+func childPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func rawJSON(v interface{}) json.RawMessage {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+// 두 값의 차이를 경로 순서로 반환합니다 (객체는 필드별로, 배열은 위치별로 비교)
+func diffValues(path string, from, to interface{}) []Change {
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil:
+		return []Change{{Path: path, Op: changeAdded, To: rawJSON(to)}}
+	case to == nil:
+		return []Change{{Path: path, Op: changeRemoved, From: rawJSON(from)}}
+	}
+
+	switch f := from.(type) {
+	case map[string]interface{}:
+		t, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+		names := make([]string, 0, len(f)+len(t))
+		for name := range f {
+			names = append(names, name)
+		}
+		for name := range t {
+			if _, ok := f[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		var changes []Change
+		for _, name := range names {
+			changes = append(changes, diffValues(childPath(path, name), f[name], t[name])...)
+		}
+		return changes
+	case []interface{}:
+		t, ok := to.([]interface{})
+		if !ok {
+			break
+		}
+		var changes []Change
+		for i := 0; i < len(f) || i < len(t); i++ {
+			var a, b interface{}
+			if i < len(f) {
+				a = f[i]
+			}
+			if i < len(t) {
+				b = t[i]
+			}
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(f):
+				changes = append(changes, Change{Path: p, Op: changeAdded, To: rawJSON(b)})
+			case i >= len(t):
+				changes = append(changes, Change{Path: p, Op: changeRemoved, From: rawJSON(a)})
+			default:
+				changes = append(changes, diffValues(p, a, b)...)
+			}
+		}
+		return changes
+	}
+
+	fromJSON, toJSON := rawJSON(from), rawJSON(to)
+	if bytes.Equal(fromJSON, toJSON) {
+		return nil
+	}
+	return []Change{{Path: path, Op: changeChanged, From: fromJSON, To: toJSON}}
+}
+
+// StockDiff 는 키의 두 버전 사이의 차이입니다
+type StockDiff struct {
+	Key     string       `json:"key"`
+	From    StockVersion `json:"from"`
+	To      StockVersion `json:"to"`
+	Changes []Change     `json:"changes"`
+}
+
+// 키의 두 버전(from, to: 버전 번호 또는 RFC3339 시각)을 비교합니다
+// to 를 비우면 최신 버전, from 을 비우면 to 의 바로 이전 버전
+func diffVersions(key, from, to string) (StockDiff, error) {
+	key, err := resolveStockKey(key, time.Now())
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return StockDiff{}, fmt.Errorf("%w: key not found", errVersionNotFound)
+	}
+	if err != nil {
+		return StockDiff{}, err
+	}
+	versions, err := listVersions(key)
+	if err != nil {
+		return StockDiff{}, err
+	}
+	if len(versions) == 0 {
+		return StockDiff{}, fmt.Errorf("%w: key not found", errVersionNotFound)
+	}
+
+	ti, err := selectVersion(versions, to, 0)
+	if err != nil {
+		return StockDiff{}, err
+	}
+	fi, err := selectVersion(versions, from, ti+1)
+	if err != nil {
+		return StockDiff{}, err
+	}
+	d := StockDiff{Key: key, From: versions[fi], To: versions[ti]}
+	d.Changes = diffValues("", decodeVersionValue(d.From), decodeVersionValue(d.To))
+	if d.Changes == nil {
+		d.Changes = []Change{}
+	}
+	return d, nil
+}
+
+// /diff?key=stock:20250429:KR7005930003&from=...&to=... : 두 버전(버전 번호 또는 RFC3339 시각) 사이의 변경
+func diffHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	w.Header().Set("Content-Type", "application/json")
+	if q.Get("key") == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Missing 'key' parameter"})
+		return
+	}
+
+	d, err := diffVersions(q.Get("key"), q.Get("from"), q.Get("to"))
+	if err != nil {
+		code := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, errVersionNotFound):
+			code = http.StatusNotFound
+		case errors.Is(err, errInvalidVersion):
+			code = http.StatusBadRequest
+		}
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	json.NewEncoder(w).Encode(d)
+}
+
+func (v StockVersion) proto() *pb.StockVersion {
+	p := &pb.StockVersion{Version: v.Version, Deleted: v.Deleted}
+	if v.Time != nil {
+		p.Time = v.Time.Format(time.RFC3339Nano)
+	}
+	return p
+}
+
+func (s *stockServer) GetDiff(ctx context.Context, req *pb.DiffRequest) (*pb.StockDiff, error) {
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing key")
+	}
+	d, err := diffVersions(req.Key, req.From, req.To)
+	switch {
+	case errors.Is(err, errVersionNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errInvalidVersion):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.StockDiff{Key: d.Key, From: d.From.proto(), To: d.To.proto(), Changes: make([]*pb.FieldChange, len(d.Changes))}
+	for i, c := range d.Changes {
+		resp.Changes[i] = &pb.FieldChange{Path: c.Path, Op: c.Op, From: string(c.From), To: string(c.To)}
+	}
+	return resp, nil
+}
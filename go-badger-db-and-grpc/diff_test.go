@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+func putDoc(t *testing.T, key, doc string) {
+	t.Helper()
+	err := db.Update(func(txn *badger.Txn) error {
+		return putValue(txn, []byte(key), []byte(doc))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func changeSummary(changes []Change) []string {
+	out := make([]string, len(changes))
+	for i, c := range changes {
+		out[i] = c.Op + " " + c.Path + " " + string(c.From) + " -> " + string(c.To)
+	}
+	return out
+}
+
+func TestDiffVersions(t *testing.T) {
+	openTestDB(t)
+	const key = "stock:20250429:KR7000660001"
+	putDoc(t, key, `{"code":"KR7000660001","close":200000,"volume":{"G1":10},"sellPrice":[201000,201500]}`)
+	time.Sleep(5 * time.Millisecond)
+	between := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	putDoc(t, key, `{"code":"KR7000660001","close":201000,"volume":{"G1":15},"sellPrice":[201500],"tradeTime":"09:00:30.0"}`)
+	putDoc(t, key, `{"code":"KR7000660001","close":201000,"volume":{"G1":15},"sellPrice":[201500],"tradeTime":"09:00:30.0"}`)
+
+	versions, err := listVersions(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 3 || versions[0].Version <= versions[2].Version || versions[2].Time == nil {
+		t.Fatalf("versions = %+v", versions)
+	}
+
+	// 기본값: 최신과 바로 이전 버전 (같은 값)
+	d, err := diffVersions(key, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Changes) != 0 || d.To.Version != versions[0].Version {
+		t.Errorf("latest diff = %+v", d)
+	}
+
+	// 시각으로 고른 첫 버전과 최신 버전
+	d, err = diffVersions(key, between.Format(time.RFC3339Nano), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"changed close 200000 -> 201000",
+		"changed sellPrice[0] 201000 -> 201500",
+		"removed sellPrice[1] 201500 -> ",
+		"added tradeTime  -> \"09:00:30.0\"",
+		"changed volume.G1 10 -> 15",
+	}
+	got := changeSummary(d.Changes)
+	if len(got) != len(want) {
+		t.Fatalf("changes = %q", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if _, err := diffVersions(key, "999999", ""); err == nil {
+		t.Error("expected error for a version that is not kept")
+	}
+	if _, err := diffVersions(key, "", between.Add(-time.Hour).Format(time.RFC3339)); err == nil {
+		t.Error("expected error for a time before the first version")
+	}
+}
+
+func TestDiffHandler(t *testing.T) {
+	openTestDB(t)
+	const key = "stock:20250429:KR7000660001"
+	putDoc(t, key, `{"code":"KR7000660001","close":200000}`)
+	putDoc(t, key, `{"code":"KR7000660001","close":199500}`)
+
+	rec := httptest.NewRecorder()
+	diffHandler(rec, httptest.NewRequest(http.MethodGet, "/diff?key="+key, nil))
+	var d StockDiff
+	if err := json.Unmarshal(rec.Body.Bytes(), &d); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || len(d.Changes) != 1 || d.Changes[0].Path != "close" || string(d.Changes[0].To) != "199500" {
+		t.Errorf("status = %d, diff = %s", rec.Code, rec.Body)
+	}
+
+	cases := []struct {
+		query string
+		code  int
+	}{
+		{"", http.StatusBadRequest},
+		{"?key=" + key + "&from=yesterday", http.StatusBadRequest},
+		{"?key=stock:20250429:KR7005930003", http.StatusNotFound},
+		{"?key=" + key + "&to=999999", http.StatusNotFound},
+	}
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		diffHandler(rec, httptest.NewRequest(http.MethodGet, "/diff"+c.query, nil))
+		if rec.Code != c.code {
+			t.Errorf("%s: status = %d, want %d", c.query, rec.Code, c.code)
+		}
+	}
+}
+
+func TestKeepsVersions(t *testing.T) {
+	for key, want := range map[string]bool{
+		"stock:20250429:KR7005930003":            true,
+		"vtime:stock:20250429:KR7005930003":      true,
+		"tick:KR7005930003:20250429090001":       false,
+		"vtime:tick:KR7005930003:20250429090001": false,
+		"vi:KR7005930003:20250429:090001:static": false,
+		"ratelimit:/get:1745884801":              false,
+		"validation:stock:20250429:KR7005930003": false,
+		cacheProbeKey:                            false,
+	} {
+		if got := keepsVersions([]byte(key)); got != want {
+			t.Errorf("keepsVersions(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
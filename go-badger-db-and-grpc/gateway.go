@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+// gRPC-Gateway 핸들러를 생성합니다
+// get_stockmaster.proto 의 google.api.http 규칙에 따라 REST 요청(GET /v1/stocks/{key})을
+// grpcAddr 의 StockService 호출로 변환하고, 응답 메시지를 JSON 으로 반환합니다
+func newGatewayHandler(ctx context.Context, grpcAddr string) (http.Handler, error) {
+	mux := runtime.NewServeMux(runtime.WithOutgoingHeaderMatcher(outgoingHeader))
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := pb.RegisterStockServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+// gRPC 응답 헤더(metadata)를 HTTP 헤더로 변환합니다
+// cache-control 은 KrakenD 등 HTTP 캐시가 읽을 수 있도록 그대로, 나머지는 기본 규칙(Grpc-Metadata- 접두어)으로 전달
+func outgoingHeader(key string) (string, bool) {
+	if key == "cache-control" {
+		return "Cache-Control", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
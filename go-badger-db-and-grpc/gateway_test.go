@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+	"google.golang.org/grpc"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+func TestGatewayGetStockMaster(t *testing.T) {
+	// 전역 db 를 in-memory 로 열고 테스트 데이터 저장
+	var err error
+	db, err = badger.Open(badger.DefaultOptions("").WithInMemory(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	initData()
+
+	// 임의 포트로 gRPC 서버 실행
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterStockServiceServer(grpcServer, &stockServer{})
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gwHandler, err := newGatewayHandler(ctx, lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 키에 ':' 가 포함되어도 하나의 경로 파라미터로 전달되어야 함
+	rec := httptest.NewRecorder()
+	gwHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/stocks/stock:20250428:KR7005930003", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body)
+	}
+	// 장이 끝난 거래일 데이터는 KrakenD 가 캐시할 수 있도록 Cache-Control 전달
+	if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=86400" {
+		t.Errorf("Cache-Control = %q", cc)
+	}
+
+	var resp struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	var stock StockInfo
+	if err := json.Unmarshal([]byte(resp.Value), &stock); err != nil {
+		t.Fatalf("value 가 종목 JSON 이 아닙니다: %v", err)
+	}
+	if stock.Code != "KR7005930003" {
+		t.Errorf("code = %q", stock.Code)
+	}
+
+	// 정의하지 않은 경로
+	rec = httptest.NewRecorder()
+	gwHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/unknown", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown path: status %d, want 404", rec.Code)
+	}
+}
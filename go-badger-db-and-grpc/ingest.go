@@ -0,0 +1,601 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// 피드 메시지 종류
+const (
+	feedTrade = "trade" // 체결
+	feedQuote = "quote" // 호가
+)
+
+// 보드를 지정하지 않은 메시지의 보드 (정규장)
+const defaultFeedBoard = "G1"
+
+// feedMessage 는 피드 한 줄(JSON)입니다
+type feedMessage struct {
+	Type  string `json:"type"` // trade, quote
+	ISIN  string `json:"isin"`
+	Date  string `json:"date"` // YYYYMMDD
+	Time  string `json:"time"` // HH:MM:SS.ffffff (한국 시간)
+	Seq   int64  `json:"seq"`  // 종목별 순번 (0 이면 순서를 확인하지 않음)
+	Board string `json:"board"`
+
+	// 체결
+	Price  int64 `json:"price"`
+	Volume int64 `json:"volume"`
+
+	// 호가 (10단계)
+	SellPrice  []int64 `json:"sellPrice"`
+	SellVolume []int64 `json:"sellVolume"`
+	BuyPrice   []int64 `json:"buyPrice"`
+	BuyVolume  []int64 `json:"buyVolume"`
+}
+
+// 메시지의 이벤트 시각
+func (m *feedMessage) eventTime() (time.Time, error) {
+	return time.ParseInLocation("20060102 15:04:05.999999999", m.Date+" "+m.Time, seoul)
+}
+
+func (m *feedMessage) key() string {
+	return stockKeyPrefix + m.Date + ":" + m.ISIN
+}
+
+func (m *feedMessage) board() string {
+	if m.Board == "" {
+		return defaultFeedBoard
+	}
+	return m.Board
+}
+
+// 한 줄을 메시지로 읽고 필수 필드를 확인합니다
+func parseFeedMessage(line []byte) (feedMessage, time.Time, error) {
+	var m feedMessage
+	if err := json.Unmarshal(line, &m); err != nil {
+		return m, time.Time{}, err
+	}
+	if m.ISIN == "" || !dateRe.MatchString(m.Date) {
+		return m, time.Time{}, errors.New("missing isin or invalid date")
+	}
+	at, err := m.eventTime()
+	if err != nil {
+		return m, at, fmt.Errorf("invalid time %q", m.Time)
+	}
+	switch m.Type {
+	case feedTrade:
+		if m.Price <= 0 {
+			return m, at, errors.New("trade needs a positive price")
+		}
+		if m.Volume < 0 {
+			return m, at, errors.New("trade volume must not be negative")
+		}
+	case feedQuote:
+	default:
+		return m, at, fmt.Errorf("unknown type %q", m.Type)
+	}
+	return m, at, nil
+}
+
+// 체결을 종목 문서에 반영합니다 (시가/고가/저가와 시각, 종가, 보드별/누적 거래량과 거래대금)
+func applyTrade(doc map[string]interface{}, m *feedMessage) {
+	p, v := m.Price, m.Volume
+	if docInt(doc, "open") == 0 {
+		doc["open"], doc["openTime"] = p, m.Time
+	}
+	if h := docInt(doc, "high"); h == 0 || p > h {
+		doc["high"], doc["highTime"] = p, m.Time
+	}
+	if l := docInt(doc, "low"); l == 0 || p < l {
+		doc["low"], doc["lowTime"] = p, m.Time
+	}
+	doc["close"], doc["tradeTime"] = p, m.Time
+
+	board := m.board()
+	volume, amount := docMap(doc, "volume"), docMap(doc, "amount")
+	volume[board] = docInt(volume, board) + v
+	amount[board] = docFloat(amount, board) + float64(p*v)
+	doc["totalAccumQuantity"] = docInt(doc, "totalAccumQuantity") + v
+	doc["totalAccumAmount"] = docFloat(doc, "totalAccumAmount") + float64(p*v)
+}
+
+// 호가를 종목 문서의 limitPrice 보드에 반영합니다
+func applyQuote(doc map[string]interface{}, m *feedMessage) {
+	sum := func(vs []int64) (n int64) {
+		for _, v := range vs {
+			n += v
+		}
+		return n
+	}
+	b := docMap(docMap(doc, "limitPrice"), m.board())
+	b["dt"] = m.Date[:4] + "-" + m.Date[4:6] + "-" + m.Date[6:] + "T" + m.Time
+	b["sellPrice"], b["sellVolume"] = m.SellPrice, m.SellVolume
+	b["buyPrice"], b["buyVolume"] = m.BuyPrice, m.BuyVolume
+	b["sellVolumeTotal"], b["buyVolumeTotal"] = sum(m.SellVolume), sum(m.BuyVolume)
+}
+
+// 문서의 숫자 필드 (json.Number, 없으면 0)
+func docInt(doc map[string]interface{}, key string) int64 {
+	switch v := doc[key].(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return int64(f)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+func docFloat(doc map[string]interface{}, key string) float64 {
+	switch v := doc[key].(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// 문서의 객체 필드 (없거나 null 이면 새로 만듦)
+func docMap(doc map[string]interface{}, key string) map[string]interface{} {
+	m, ok := doc[key].(map[string]interface{})
+	if !ok {
+		m = map[string]interface{}{}
+		doc[key] = m
+	}
+	return m
+}
+
+// 저장된 종목 문서를 읽습니다 (숫자는 원래 표기를 유지하도록 json.Number, 없으면 새 문서)
+func loadDocument(txn *badger.Txn, m *feedMessage) (map[string]interface{}, error) {
+	item, err := txn.Get([]byte(m.key()))
+	if err == badger.ErrKeyNotFound {
+		return map[string]interface{}{"code": m.ISIN, "baseDate": m.Date}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = item.Value(func(val []byte) error {
+		raw, err := decodeValue(val)
+		if err != nil {
+			return err
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		return dec.Decode(&doc)
+	})
+	if err == nil && doc == nil {
+		err = errors.New("stored value is not a JSON object")
+	}
+	return doc, err
+}
+
+// 피드에서 받은 메시지
+type feedItem struct {
+	source string
+	msg    feedMessage
+	at     time.Time // 이벤트 시각
+}
+
+// SourceStats 는 피드 소스 하나의 지표입니다 (/ingest/stats)
+type SourceStats struct {
+	URL           string    `json:"url"`
+	Connected     bool      `json:"connected"`
+	Done          bool      `json:"done"`      // 파일을 끝까지 읽음
+	Messages      int64     `json:"messages"`  // 받은 메시지 수
+	Applied       int64     `json:"applied"`   // 저장한 메시지 수
+	Stale         int64     `json:"stale"`     // 순번이 이전 메시지 이하여서 버린 수
+	Errors        int64     `json:"errors"`    // 읽기, 검증, 저장 실패
+	Processed     int64     `json:"processed"` // 저장, 버림, 실패로 처리를 마친 메시지 수
+	LastEventTime time.Time `json:"lastEventTime"`
+	LagMs         int64     `json:"lagMs"` // 마지막으로 저장한 메시지의 이벤트 시각부터 저장까지 걸린 시간
+	MaxLagMs      int64     `json:"maxLagMs"`
+	LastError     string    `json:"lastError,omitempty"`
+}
+
+// ingester 는 피드 소스(TCP, 파일)의 메시지를 받은 순서대로 모아 Badger 에 일괄 저장합니다
+type ingester struct {
+	batchSize     int
+	flushInterval time.Duration
+	now           func() time.Time
+	items         chan feedItem
+	lastSeq       map[string]int64 // 종목별 마지막으로 저장한 순번 (run goroutine 만 사용)
+
+	mu      sync.Mutex
+	sources map[string]*SourceStats
+}
+
+// 피드 수집기 (nil 이면 사용 안 함)
+var ingest *ingester
+
+func newIngester(batchSize int, flushInterval time.Duration) *ingester {
+	return &ingester{
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		now:           time.Now,
+		items:         make(chan feedItem, batchSize),
+		lastSeq:       make(map[string]int64),
+		sources:       make(map[string]*SourceStats),
+	}
+}
+
+// 피드 소스 목록을 읽습니다 (예: live=tcp://localhost:9000,replay=file:///data/feed.jsonl)
+func parseFeedSources(s string) (map[string]string, error) {
+	sources := make(map[string]string)
+	for _, part := range strings.Split(s, ",") {
+		name, raw, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid feed source %q (name=url)", part)
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "tcp" && u.Scheme != "file") {
+			return nil, fmt.Errorf("invalid feed url %q (tcp://host:port or file:///path)", raw)
+		}
+		sources[name] = raw
+	}
+	return sources, nil
+}
+
+func (in *ingester) update(source string, fn func(*SourceStats)) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	fn(in.sources[source])
+}
+
+func (in *ingester) fail(source string, err error) {
+	in.update(source, func(s *SourceStats) {
+		s.Errors++
+		s.LastError = err.Error()
+	})
+}
+
+// 소스 지표를 등록합니다 (이미 있으면 그대로 둠)
+func (in *ingester) register(name, rawURL string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.sources[name] == nil {
+		in.sources[name] = &SourceStats{URL: rawURL}
+	}
+}
+
+// 소스에서 메시지를 읽기 시작합니다 (ctx 가 끝날 때까지)
+func (in *ingester) start(ctx context.Context, name, rawURL string) {
+	in.mu.Lock()
+	in.sources[name] = &SourceStats{URL: rawURL}
+	in.mu.Unlock()
+
+	u, _ := url.Parse(rawURL)
+	if u.Scheme == "file" {
+		go in.readFile(ctx, name, u.Path)
+	} else {
+		go in.readTCP(ctx, name, u.Host)
+	}
+}
+
+// 파일을 처음부터 끝까지 읽습니다
+func (in *ingester) readFile(ctx context.Context, name, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		in.fail(name, err)
+		return
+	}
+	defer f.Close()
+	in.update(name, func(s *SourceStats) { s.Connected = true })
+	err = in.read(ctx, name, f)
+	in.update(name, func(s *SourceStats) { s.Connected, s.Done = false, err == nil })
+	if err != nil && ctx.Err() == nil {
+		in.fail(name, err)
+	}
+}
+
+// TCP 피드에 접속해 읽고, 연결이 끊기면 간격을 늘려가며(최대 30초) 다시 접속합니다
+func (in *ingester) readTCP(ctx context.Context, name, addr string) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", addr)
+		if err == nil {
+			backoff = time.Second
+			in.update(name, func(s *SourceStats) { s.Connected = true })
+			stop := context.AfterFunc(ctx, func() { conn.Close() })
+			err = in.read(ctx, name, conn)
+			stop()
+			conn.Close()
+			in.update(name, func(s *SourceStats) { s.Connected = false })
+			if err == nil {
+				err = io.EOF
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		in.fail(name, err)
+		log.Printf("feed %s: %v (reconnect in %s)", name, err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
+
+// 줄 단위 메시지를 읽어 저장 대기열에 넣습니다
+func (in *ingester) read(ctx context.Context, name string, r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		in.update(name, func(s *SourceStats) { s.Messages++ })
+		m, at, err := parseFeedMessage(line)
+		if err != nil {
+			in.update(name, func(s *SourceStats) {
+				s.Errors++
+				s.Processed++
+				s.LastError = err.Error()
+			})
+			continue
+		}
+		select {
+		case in.items <- feedItem{source: name, msg: m, at: at}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return sc.Err()
+}
+
+// 대기열의 메시지를 batchSize 개 또는 flushInterval 마다 모아 저장합니다 (ctx 가 끝날 때까지)
+// 끝날 때는 모으던 메시지와 대기열에 남은 메시지를 저장한 뒤 반환합니다
+func (in *ingester) run(ctx context.Context) {
+	ticker := time.NewTicker(in.flushInterval)
+	defer ticker.Stop()
+	var batch []feedItem
+	for {
+		select {
+		case it := <-in.items:
+			batch = append(batch, it)
+			if len(batch) < in.batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		case <-ctx.Done():
+			in.drain(batch)
+			return
+		}
+		in.flush(batch)
+		batch = nil
+	}
+}
+
+// 모으던 메시지와 대기열에 남은 메시지를 batchSize 개씩 저장합니다
+func (in *ingester) drain(batch []feedItem) {
+	for {
+		select {
+		case it := <-in.items:
+			batch = append(batch, it)
+			if len(batch) < in.batchSize {
+				continue
+			}
+		default:
+			if len(batch) > 0 {
+				in.flush(batch)
+			}
+			return
+		}
+		in.flush(batch)
+		batch = nil
+	}
+}
+
+// 메시지를 받은 순서대로 종목 문서에 반영해 한 트랜잭션으로 저장합니다
+// 종목별 순번이 이전 메시지 이하인 메시지는 버리고, 검증(reject)에 실패한 종목의 메시지는 에러로 셉니다
+func (in *ingester) flush(batch []feedItem) {
+	seq := make(map[string]int64)
+	var accepted []feedItem
+	for _, it := range batch {
+		m := it.msg
+		if m.Seq != 0 {
+			last, ok := seq[m.ISIN]
+			if !ok {
+				last = in.lastSeq[m.ISIN]
+			}
+			if m.Seq <= last {
+				in.update(it.source, func(s *SourceStats) { s.Stale++; s.Processed++ })
+				continue
+			}
+			seq[m.ISIN] = m.Seq
+		}
+		accepted = append(accepted, it)
+	}
+
+	rejected, committed, err := commitFeed(accepted)
+
+	now := in.now()
+	for i, it := range accepted {
+		in.update(it.source, func(s *SourceStats) {
+			s.Processed++
+			switch {
+			case i >= committed:
+				s.Errors++
+				s.LastError = err.Error()
+			case rejected[it.msg.key()] != nil:
+				s.Errors++
+				s.LastError = rejected[it.msg.key()].Error()
+			default:
+				s.Applied++
+				s.LastEventTime = it.at
+				s.LagMs = now.Sub(it.at).Milliseconds()
+				s.MaxLagMs = max(s.MaxLagMs, s.LagMs)
+			}
+		})
+	}
+	// 나눠 저장하다 실패해도 이미 저장한 메시지의 순번은 반영해야 다시 들어왔을 때 버림
+	for _, it := range accepted[:committed] {
+		if it.msg.Seq != 0 {
+			in.lastSeq[it.msg.ISIN] = it.msg.Seq
+		}
+	}
+}
+
+// 메시지를 한 트랜잭션으로 저장합니다
+// 다른 쓰기와 충돌하면 다시 시도하고, 트랜잭션이 너무 크면 나눠서 저장합니다
+// 나눠 저장하다 실패하면 앞쪽 일부만 저장되므로, 앞에서부터 저장한 메시지 수를 함께 반환합니다
+func commitFeed(items []feedItem) (map[string]error, int, error) {
+	var (
+		rejected map[string]error
+		err      error
+	)
+	for attempt := 0; attempt < 3; attempt++ {
+		err = db.Update(func(txn *badger.Txn) error {
+			var err error
+			rejected, err = applyFeed(txn, items)
+			return err
+		})
+		if err != badger.ErrConflict {
+			break
+		}
+	}
+	if err == badger.ErrTxnTooBig && len(items) > 1 {
+		half := len(items) / 2
+		rejected, n, err := commitFeed(items[:half])
+		if err != nil {
+			return rejected, n, err
+		}
+		rest, m, err := commitFeed(items[half:])
+		for k, e := range rest {
+			rejected[k] = e
+		}
+		return rejected, n + m, err
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return rejected, len(items), nil
+}
+
+// 메시지를 종목 문서에 반영합니다 (문서마다 한 번만 읽고 씀)
+// 체결은 문서의 마지막 값뿐 아니라 건마다 체결 시계열에 기록합니다
+func applyFeed(txn *badger.Txn, items []feedItem) (map[string]error, error) {
+	var keys []string
+	groups := make(map[string][]*feedMessage)
+	for i := range items {
+		k := items[i].msg.key()
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], &items[i].msg)
+	}
+
+	rejected := make(map[string]error)
+	for _, k := range keys {
+		msgs := groups[k]
+		doc, err := loadDocument(txn, msgs[0])
+		if err != nil {
+			rejected[k] = err
+			continue
+		}
+		type timedTick struct {
+			at time.Time
+			t  tick
+		}
+		var ticks []timedTick
+		for _, m := range msgs {
+			if m.Type == feedQuote {
+				applyQuote(doc, m)
+				continue
+			}
+			applyTrade(doc, m)
+			at, _ := m.eventTime()
+			halt, _ := doc["tradingHalt"].(bool)
+			ticks = append(ticks, timedTick{at, tick{Price: m.Price, AccumVolume: docInt(doc, "totalAccumQuantity"), Halt: halt}})
+		}
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		var verr *ValidationError
+		if err := putValue(txn, []byte(k), data); errors.As(err, &verr) {
+			rejected[k] = err
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, t := range ticks {
+			if err := saveTick(txn, msgs[0].ISIN, t.at, t.t); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rejected, nil
+}
+
+// /ingest?source=replay : 요청 본문의 줄 단위 메시지를 피드 소스 source(기본 http)로 수집 (POST)
+// 메시지는 대기열에 넣은 뒤 응답하므로, 저장이 끝났는지는 /ingest/stats 의 processed 로 확인합니다
+func ingestHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if ingest == nil {
+		http.Error(w, "Ingestion disabled", http.StatusServiceUnavailable)
+		return
+	}
+	name := r.URL.Query().Get("source")
+	if name == "" {
+		name = "http"
+	}
+	ingest.register(name, "http")
+	if err := ingest.read(r.Context(), name, r.Body); err != nil {
+		ingest.fail(name, err)
+		http.Error(w, "Failed to read feed messages", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// /ingest/stats : 피드 소스별 수신, 저장, 지연 지표
+func ingestStatsHandler(w http.ResponseWriter, r *http.Request) {
+	stats := map[string]SourceStats{}
+	if ingest != nil {
+		ingest.mu.Lock()
+		for name, s := range ingest.sources {
+			stats[name] = *s
+		}
+		ingest.mu.Unlock()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+const testFeed = `{"type":"trade","isin":"KR7000660001","date":"20250429","time":"09:00:01.5","seq":1,"price":200000,"volume":10}
+{"type":"trade","isin":"KR7000660001","date":"20250429","time":"09:00:30.0","seq":2,"price":201000,"volume":5}
+{"type":"quote","isin":"KR7000660001","date":"20250429","time":"09:00:31.0","seq":3,"sellPrice":[201500,202000],"sellVolume":[7,3],"buyPrice":[201000,200500],"buyVolume":[4,6]}
+{"type":"trade","isin":"KR7000660001","date":"20250429","time":"09:00:29.0","seq":2,"price":1,"volume":1}
+not json
+{"type":"trade","isin":"KR7000660001","date":"20250429","time":"09:01:10.0","seq":4,"price":199500,"volume":20}
+`
+
+// 소스 지표가 cond 를 만족할 때까지 기다립니다
+func waitSource(t *testing.T, in *ingester, name string, cond func(SourceStats) bool) SourceStats {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		in.mu.Lock()
+		s := *in.sources[name]
+		in.mu.Unlock()
+		if cond(s) {
+			return s
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s: stats = %+v", name, s)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestIngestFileReplay(t *testing.T) {
+	openTestDB(t)
+	path := filepath.Join(t.TempDir(), "feed.jsonl")
+	if err := os.WriteFile(path, []byte(testFeed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := newIngester(2, 10*time.Millisecond)
+	go in.run(ctx)
+	in.start(ctx, "replay", "file://"+path)
+
+	s := waitSource(t, in, "replay", func(s SourceStats) bool { return s.Done && s.Applied+s.Stale == 5 })
+	if s.Messages != 6 || s.Applied != 4 || s.Stale != 1 || s.Errors != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+
+	value, err := loadValue("stock:20250429:KR7000660001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Open, High, Low, Close      int64
+		OpenTime, HighTime, LowTime string
+		TradeTime                   string
+		Volume                      map[string]int64
+		TotalAccumQuantity          int64
+	}
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Open != 200000 || doc.High != 201000 || doc.Low != 199500 || doc.Close != 199500 ||
+		doc.HighTime != "09:00:30.0" || doc.LowTime != "09:01:10.0" || doc.TradeTime != "09:01:10.0" ||
+		doc.Volume["G1"] != 35 || doc.TotalAccumQuantity != 35 {
+		t.Errorf("doc = %+v", doc)
+	}
+
+	// 호가는 limitPrice 보드로 저장되어 호가 API 로 읽을 수 있음
+	book, err := loadOrderBook("stock:20250429:KR7000660001", "G1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *book.BestAsk != 201500 || *book.BestBid != 201000 || book.AskDepth != 10 {
+		t.Errorf("book = %+v", book)
+	}
+
+	// 체결마다 시계열에 기록
+	from, to, interval, _ := parseCandleQuery("20250429", "20250429", "1m")
+	candles, err := loadCandles("KR7000660001", from, to, interval)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 2 || candles[0].Volume != 15 || candles[0].High != 201000 || candles[1].Volume != 20 {
+		t.Errorf("candles = %+v", candles)
+	}
+}
+
+func TestIngestFlushOnShutdown(t *testing.T) {
+	openTestDB(t)
+	// 배치가 차지 않고 주기도 오지 않아, 종료할 때 저장하지 않으면 모두 잃음
+	in := newIngester(100, time.Hour)
+	in.register("replay", "file")
+	if err := in.read(context.Background(), "replay", strings.NewReader(testFeed)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	in.run(ctx)
+
+	s := waitSource(t, in, "replay", func(SourceStats) bool { return true })
+	if s.Processed != 6 || s.Applied != 4 || s.Stale != 1 || s.Errors != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+	value, err := loadValue("stock:20250429:KR7000660001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["close"] != float64(199500) {
+		t.Errorf("close = %v", doc["close"])
+	}
+}
+
+// 트랜잭션이 너무 커서 나눠 저장하다 뒤쪽만 실패하면, 앞쪽은 저장한 것으로 세고 순번도 반영해야 함
+func TestIngestPartialCommit(t *testing.T) {
+	var err error
+	// 한 트랜잭션에 약 10KB, 값 하나에 1KB 까지만 쓸 수 있는 DB
+	db, err = badger.Open(badger.DefaultOptions("").WithInMemory(true).WithMemTableSize(1 << 16).
+		WithValueThreshold(1 << 10).WithLoggingLevel(badger.ERROR))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	setValueFormat(t, formatRaw)
+
+	// 40 종목의 호가는 한 트랜잭션에 들어가지 않아 나눠 저장하고, 마지막 종목의 호가는 값 크기 한도를 넘어 실패함
+	prices := func(n int) string { return strings.TrimSuffix(strings.Repeat("100000,", n), ",") }
+	var feed strings.Builder
+	for i := 0; i < 40; i++ {
+		fmt.Fprintf(&feed, `{"type":"quote","isin":"KR7%09d","date":"20250429","time":"09:00:01.0","seq":1,"sellPrice":[%s]}`+"\n", i, prices(50))
+	}
+	fmt.Fprintf(&feed, `{"type":"quote","isin":"KR7999999999","date":"20250429","time":"09:00:01.0","seq":1,"sellPrice":[%s]}`+"\n", prices(500))
+
+	in := newIngester(100, time.Hour)
+	in.register("replay", "file")
+	if err := in.read(context.Background(), "replay", strings.NewReader(feed.String())); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	in.run(ctx)
+
+	s := waitSource(t, in, "replay", func(SourceStats) bool { return true })
+	// 마지막 종목과 같은 트랜잭션에 있던 메시지만 실패함
+	if s.Processed != 41 || s.Applied == 0 || s.Errors == 0 || s.Applied+s.Errors != 41 {
+		t.Errorf("stats = %+v", s)
+	}
+	if _, err := loadValue("stock:20250429:KR7000000000"); err != nil {
+		t.Errorf("committed quote not stored: %v", err)
+	}
+	if int64(len(in.lastSeq)) != s.Applied || in.lastSeq["KR7999999999"] != 0 {
+		t.Errorf("lastSeq = %v", in.lastSeq)
+	}
+}
+
+func TestParseFeedMessageErrors(t *testing.T) {
+	for line, want := range map[string]string{
+		`{"type":"trade","isin":"KR7000660001","date":"20250429","time":"09:00:01","price":0,"volume":1}`:     "positive price",
+		`{"type":"trade","isin":"KR7000660001","date":"20250429","time":"09:00:01","price":1000,"volume":-1}`: "volume must not be negative",
+	} {
+		if _, _, err := parseFeedMessage([]byte(line)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: err = %v, want %q", line, err, want)
+		}
+	}
+}
+
+func TestIngestTCP(t *testing.T) {
+	openTestDB(t)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte(strings.SplitAfterN(testFeed, "\n", 2)[0]))
+		conn.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := newIngester(10, 10*time.Millisecond)
+	in.now = func() time.Time {
+		return time.Date(2025, 4, 29, 9, 0, 3, 500_000_000, seoul)
+	}
+	go in.run(ctx)
+	in.start(ctx, "live", "tcp://"+lis.Addr().String())
+
+	// 연결이 끊기면 에러로 기록하고 다시 접속을 시도함
+	s := waitSource(t, in, "live", func(s SourceStats) bool { return s.Applied == 1 && !s.Connected && s.Errors > 0 })
+	if s.LagMs != 2000 || s.MaxLagMs != 2000 {
+		t.Errorf("lag = %d, max %d", s.LagMs, s.MaxLagMs)
+	}
+}
+
+func TestParseFeedSources(t *testing.T) {
+	sources, err := parseFeedSources("live=tcp://localhost:9000, replay=file:///tmp/feed.jsonl")
+	if err != nil || len(sources) != 2 || sources["replay"] != "file:///tmp/feed.jsonl" {
+		t.Errorf("sources = %v, %v", sources, err)
+	}
+	for _, bad := range []string{"tcp://localhost:9000", "live=http://localhost"} {
+		if _, err := parseFeedSources(bad); err == nil {
+			t.Errorf("%q: no error", bad)
+		}
+	}
+}
+
+func TestIngestHandler(t *testing.T) {
+	openTestDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	prev := ingest
+	ingest = newIngester(10, 10*time.Millisecond)
+	t.Cleanup(func() { ingest = prev })
+	go ingest.run(ctx)
+
+	srv := httptest.NewServer(http.HandlerFunc(ingestHandler))
+	defer srv.Close()
+	resp, err := http.Post(srv.URL+"?source=replay", "application/x-ndjson", strings.NewReader(testFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+
+	// 처리를 마친 수는 저장, 버림, 읽기 실패를 모두 셈
+	s := waitSource(t, ingest, "replay", func(s SourceStats) bool { return s.Processed == 6 })
+	if s.Applied != 4 || s.Stale != 1 || s.Errors != 1 || s.URL != "http" {
+		t.Errorf("stats = %+v", s)
+	}
+	if _, err := loadValue("stock:20250429:KR7000660001"); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err = http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d", resp.StatusCode)
+	}
+}
@@ -2,9 +2,13 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/dgraph-io/badger/v4"
 
@@ -12,13 +16,26 @@ import (
 
 	"net"
 
+	"github.com/yiminan/go-examples/go-badger-db-and-grpc/calendar"
 	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+	"github.com/yiminan/go-examples/go-ratelimit"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 var db *badger.DB
 
+// /set, /get 의 레이트 리밋 (nil 이면 제한 없음)
+var limiter *ratelimit.Limiter
+
+// 한도를 넘은 요청의 429 응답
+func rejectRateLimited(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "Too many requests", http.StatusTooManyRequests)
+}
+
 type stockServer struct {
 	pb.UnimplementedStockServiceServer
 }
@@ -26,42 +43,145 @@ type stockServer struct {
 func (s *stockServer) GetStockMaster(ctx context.Context, req *pb.StockRequest) (*pb.StockMaster, error) {
 	log.Printf("Received request for key: %s", req.Key)
 
-	// BadgerDB에서 데이터 조회
-	var valCopy []byte
-	err := db.View(func(txn *badger.Txn) error {
-		item, err := txn.Get([]byte(req.Key))
-		if err != nil {
-			return err
-		}
-		valCopy, err = item.ValueCopy(nil)
-		return err
-	})
+	// 캐시 또는 BadgerDB에서 데이터 조회
+	value, err := loadValue(req.Key)
 
 	if err != nil {
 		// 키를 찾을 수 없는 경우 기본 응답 반환
 		return &pb.StockMaster{Value: fmt.Sprintf("Stock Info for key: %s (not found in DB)", req.Key)}, nil
 	}
 
+	// 기업 행위로 수정한 가격은 새 기업 행위가 등록되면 바뀌므로 캐시하지 않음
+	cc := cacheControl(req.Key, time.Now())
+	if req.Adjusted {
+		if value, err = adjustStockDocument(value); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		cc = "no-cache"
+	}
+
+	// gRPC-Gateway 가 Cache-Control 헤더로 전달
+	grpc.SetHeader(ctx, metadata.Pairs("cache-control", cc))
+
 	// 비즈니스 로직에 따라 응답
-	return &pb.StockMaster{Value: string(valCopy)}, nil
+	return &pb.StockMaster{Value: value}, nil
 }
 
 func main() {
-	// BadgerDB를 in-memory로 오픈
-	opts := badger.DefaultOptions("").WithInMemory(true)
+	rate := flag.String("rate", "", "/set, /get 의 클라이언트(IP)별 기본 한도 rate:burst (예: 10:20, 비우면 제한 없음)")
+	routeLimits := flag.String("route-limits", "", "라우트별 클라이언트 한도 (예: /set=1:5,/get=50:100)")
+	clientLimits := flag.String("client-limits", "", "X-API-Key 별 한도 (예: partner-key=100:200)")
+	clusterRate := flag.Int("cluster-rate", 0, "라우트별 초당 전체 요청 수 (모든 클라이언트 합계, 이 프로세스 안에서만 적용, 0 이면 사용 안 함)")
+	cacheSize := flag.Int64("cache-size", 64<<20, "조회 결과 캐시 크기 (바이트, 0 이면 사용 안 함)")
+	encoding := flag.String("value-encoding", "zstd", "새로 저장하는 값의 형식 (raw, zstd, snappy, proto, proto+zstd)")
+	validation := flag.String("validation", validationWarn, "종목 스냅샷 가격 검증에 실패했을 때 처리 (reject, warn, flag, off)")
+	holidays := flag.String("holidays", "", "KRX 휴장일 파일 (예: holidays.txt, 비우면 주말만 휴장)")
+	feed := flag.String("feed", "", "시세 피드 소스 (예: live=tcp://localhost:9000,replay=file:///data/feed.jsonl)")
+	ingestBatch := flag.Int("ingest-batch", 200, "피드 메시지를 한 트랜잭션에 저장할 최대 개수")
+	keepVersions := flag.Int("keep-versions", 10, "stock: 키마다 보관할 값의 버전 수 (최신 포함, /diff 로 비교, 값 크기 x 버전 수만큼 메모리 사용)")
+	ingestFlush := flag.Duration("ingest-flush", 100*time.Millisecond, "피드 메시지를 모아 저장하는 최대 간격")
+	flag.Parse()
+
+	if *holidays != "" {
+		cal, err := calendar.Load(*holidays)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tradingCalendar = cal
+	}
+
+	if !validationModes[*validation] {
+		log.Fatalf("unknown validation mode %q", *validation)
+	}
+	validationMode = *validation
+
+	if *ingestBatch <= 0 {
+		log.Fatalf("-ingest-batch must be positive: %d", *ingestBatch)
+	}
+	if *ingestFlush <= 0 {
+		log.Fatalf("-ingest-flush must be positive: %s", *ingestFlush)
+	}
+
+	format, ok := valueEncodings[*encoding]
+	if !ok {
+		log.Fatalf("unknown value encoding %q", *encoding)
+	}
+	valueFormat = format
+
 	var err error
+	limiter, err = ratelimit.FromFlags(*rate, *routeLimits, *clientLimits, *clusterRate, func() *badger.DB { return db }, rejectRateLimited)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// BadgerDB를 in-memory로 오픈
+	opts := badger.DefaultOptions("").WithInMemory(true).WithNumVersionsToKeep(*keepVersions)
 	db, err = badger.Open(opts)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer db.Close()
 
+	// 조회 결과 캐시 (Badger 변경 구독으로 무효화)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := startCache(ctx, *cacheSize); err != nil {
+		log.Fatal(err)
+	}
+	// 순위 집계 (종목 스냅샷 변경 구독으로 갱신)
+	if err := startOverview(ctx); err != nil {
+		log.Fatal(err)
+	}
+
 	// 테스트 데이터 저장
 	initData()
+	if stats, err := storageStats(); err == nil {
+		log.Printf("storage: %d keys, %d bytes stored (%s), %d bytes raw, %.1f%% saved",
+			stats.Keys, stats.StoredBytes, stats.Encoding, stats.RawBytes, stats.SavedRatio*100)
+	}
+
+	// 시세 피드 수집 (-feed 소스와 POST /ingest)
+	ingest = newIngester(*ingestBatch, *ingestFlush)
+	go ingest.run(ctx)
+	if *feed != "" {
+		sources, err := parseFeedSources(*feed)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for name, u := range sources {
+			ingest.start(ctx, name, u)
+		}
+	}
 
 	// HTTP 서버 설정
-	http.HandleFunc("/set", setHandler)
-	http.HandleFunc("/get", getHandler)
+	http.HandleFunc("/set", limiter.Wrap("/set", setHandler))
+	http.HandleFunc("/get", limiter.Wrap("/get", getHandler))
+	http.HandleFunc("/stocks/{key}", stockHandler)
+	http.HandleFunc("/stocks/{key}/orderbook", orderBookHandler)
+	http.HandleFunc("/candles/{isin}", candlesHandler)
+	http.HandleFunc("/vi/{isin}/events", viEventsHandler)
+	http.HandleFunc("/vi/stream", viStreamHandler)
+	http.HandleFunc("/validation", validationHandler)
+	http.HandleFunc("/diff", diffHandler)
+	http.HandleFunc("/corporate-actions", corpActionsHandler)
+	http.HandleFunc("/corporate-actions/{isin}", corpActionListHandler)
+	http.HandleFunc("/market/rankings", rankingsHandler)
+	http.HandleFunc("/market/overview", marketOverviewHandler)
+	http.HandleFunc("/calendar/open", marketOpenHandler)
+	http.HandleFunc("/calendar/next-session", nextSessionHandler)
+	http.HandleFunc("/calendar/previous-trading-day", previousTradingDayHandler)
+	http.HandleFunc("/ingest", ingestHandler)
+	http.HandleFunc("/ingest/stats", ingestStatsHandler)
+	http.HandleFunc("/cache/stats", cacheStatsHandler)
+	http.HandleFunc("/storage/stats", storageStatsHandler)
+	http.HandleFunc("/storage/migrate", storageMigrateHandler)
+
+	// gRPC-Gateway: REST 요청(/v1/...)을 gRPC StockService 호출로 변환
+	gwHandler, err := newGatewayHandler(ctx, "localhost:50051")
+	if err != nil {
+		log.Fatalf("Failed to register gateway: %v", err)
+	}
+	http.Handle("/v1/", gwHandler)
 
 	// HTTP 서버를 goroutine으로 실행
 	go func() {
@@ -106,9 +226,16 @@ func setHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err := db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(kv.Key), []byte(kv.Value))
+		return putValue(txn, []byte(kv.Key), []byte(kv.Value))
 	})
 
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": "Invalid snapshot", "violations": verr.Violations})
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to store value", http.StatusInternalServerError)
 		return
@@ -130,27 +257,53 @@ func getHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var valCopy []byte
-	err := db.View(func(txn *badger.Txn) error {
-		item, err := txn.Get([]byte(key))
-		if err != nil {
-			return err
-		}
-		valCopy, err = item.ValueCopy(nil)
-		return err
-	})
-
+	value, err := loadValue(key)
 	if err != nil {
 		http.Error(w, "Key not found", http.StatusNotFound)
 		return
 	}
 
+	w.Header().Set("Cache-Control", cacheControl(key, time.Now()))
 	json.NewEncoder(w).Encode(KeyValue{
 		Key:   key,
-		Value: string(valCopy),
+		Value: value,
 	})
 }
 
+// 저장된 종목 JSON 을 문자열로 감싸지 않고 그대로 반환합니다 (Gateway 에서 필드 단위로 필터링/병합하기 위함)
+func stockHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := r.PathValue("key")
+	value, err := loadValue(key)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Key not found"})
+		return
+	}
+	if !json.Valid([]byte(value)) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Stored value is not JSON"})
+		return
+	}
+	cc := cacheControl(key, time.Now())
+	if adjustedParam(r) {
+		if value, err = adjustStockDocument(value); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Failed to adjust prices"})
+			return
+		}
+		cc = "no-cache" // 새 기업 행위가 등록되면 바뀜
+	}
+	w.Header().Set("Cache-Control", cc)
+	io.WriteString(w, value)
+}
+
 func initData() {
 	stockData := `{
   "code": "KR7005930003",
@@ -488,6 +641,6 @@ func initData() {
 }`
 
 	db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte("stock:20250428:KR7005930003"), []byte(stockData))
+		return putValue(txn, []byte("stock:20250428:KR7005930003"), []byte(stockData))
 	})
 }
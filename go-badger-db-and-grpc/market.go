@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/yiminan/go-examples/go-badger-db-and-grpc/calendar"
+)
+
+// 거래일 대신 쓰면 가장 최근 거래일의 스냅샷을 찾는 키 (stock:latest:<종목코드>)
+const latestKeyDate = "latest"
+
+// 최근 스냅샷을 찾을 때 거슬러 올라갈 최대 거래일 수
+const maxLatestLookback = 10
+
+// 장 운영 달력 (-holidays 로 휴장일 파일을 읽지 않으면 주말만 휴장)
+var tradingCalendar = calendar.New(nil)
+
+// stock:latest:<종목코드> 를 now 기준 가장 최근 거래일부터 거슬러 올라가 저장된 스냅샷 키로 바꿉니다
+// 다른 키는 그대로 반환하고, 찾지 못하면 badger.ErrKeyNotFound
+func resolveStockKey(key string, now time.Time) (string, error) {
+	code, ok := strings.CutPrefix(key, stockKeyPrefix+latestKeyDate+":")
+	if !ok {
+		return key, nil
+	}
+	day, ok := tradingCalendar.LatestTradingDay(now)
+	var resolved string
+	err := db.View(func(txn *badger.Txn) error {
+		for i := 0; ok && i < maxLatestLookback; i++ {
+			k := stockKeyPrefix + day.Format("20060102") + ":" + code
+			if _, err := txn.Get([]byte(k)); err == nil {
+				resolved = k
+				return nil
+			} else if err != badger.ErrKeyNotFound {
+				return err
+			}
+			day, ok = tradingCalendar.PreviousTradingDay(day)
+		}
+		return badger.ErrKeyNotFound
+	})
+	return resolved, err
+}
+
+// at 쿼리 파라미터 (RFC3339, 비우면 지금)
+func atParam(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	at := r.URL.Query().Get("at")
+	if at == "" {
+		return time.Now(), true
+	}
+	t, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		http.Error(w, "Invalid 'at' parameter (RFC3339)", http.StatusBadRequest)
+		return t, false
+	}
+	return t, true
+}
+
+// /calendar/open?at=2025-04-29T10:00:00+09:00 : 장 운영 여부와 진행 중인 세션
+func marketOpenHandler(w http.ResponseWriter, r *http.Request) {
+	at, ok := atParam(w, r)
+	if !ok {
+		return
+	}
+	resp := struct {
+		At         time.Time        `json:"at"`
+		Open       bool             `json:"open"`
+		TradingDay bool             `json:"tradingDay"`
+		Holiday    string           `json:"holiday,omitempty"`
+		Session    *calendar.Period `json:"session,omitempty"`
+	}{At: at.In(calendar.KST), TradingDay: tradingCalendar.IsTradingDay(at)}
+	resp.Holiday, _ = tradingCalendar.Holiday(at)
+	if p, ok := tradingCalendar.SessionAt(at); ok {
+		resp.Open, resp.Session = true, &p
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// /calendar/next-session?at=... : at 이후에 시작하는 첫 세션
+func nextSessionHandler(w http.ResponseWriter, r *http.Request) {
+	at, ok := atParam(w, r)
+	if !ok {
+		return
+	}
+	p, ok := tradingCalendar.NextSession(at)
+	if !ok {
+		http.Error(w, "No trading day found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(p)
+}
+
+// /calendar/previous-trading-day?date=20250429 : 직전 거래일 (date 를 비우면 한국 시간 오늘)
+func previousTradingDayHandler(w http.ResponseWriter, r *http.Request) {
+	date, err := parseDateParam(r.URL.Query().Get("date"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	day, _ := time.ParseInLocation("20060102", date, calendar.KST)
+	prev, ok := tradingCalendar.PreviousTradingDay(day)
+	if !ok {
+		http.Error(w, "No trading day found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"date": date, "previousTradingDay": prev.Format("20060102")})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yiminan/go-examples/go-badger-db-and-grpc/calendar"
+)
+
+func TestResolveLatestStockKey(t *testing.T) {
+	openTestDB(t)
+	old := tradingCalendar
+	tradingCalendar = calendar.New(map[string]string{"20250501": "근로자의 날"})
+	t.Cleanup(func() { tradingCalendar = old })
+
+	putClose(t, "KR7005930003", "20250429", 49000, 10)
+	putClose(t, "KR7005930003", "20250430", 49500, 10)
+
+	for now, want := range map[string]string{
+		"2025-04-30T08:00:00+09:00": "stock:20250429:KR7005930003", // 첫 세션 전은 직전 거래일
+		"2025-04-30T10:00:00+09:00": "stock:20250430:KR7005930003",
+		"2025-05-02T10:00:00+09:00": "stock:20250430:KR7005930003", // 오늘 데이터가 없으면 휴장일을 건너뛰어 거슬러 올라감
+	} {
+		at, _ := time.Parse(time.RFC3339, now)
+		if got, err := resolveStockKey("stock:latest:KR7005930003", at); err != nil || got != want {
+			t.Errorf("%s: %s, %v (want %s)", now, got, err, want)
+		}
+	}
+	if got, _ := resolveStockKey(testStockKey, time.Now()); got != testStockKey {
+		t.Errorf("dated key resolved to %s", got)
+	}
+	if _, err := resolveStockKey("stock:latest:NONE", time.Now()); err == nil {
+		t.Error("unknown stock: no error")
+	}
+}
+
+func TestCalendarHandlers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendar/open", marketOpenHandler)
+	mux.HandleFunc("/calendar/next-session", nextSessionHandler)
+	mux.HandleFunc("/calendar/previous-trading-day", previousTradingDayHandler)
+	get := func(path string, v interface{}) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code == http.StatusOK {
+			json.Unmarshal(rec.Body.Bytes(), v)
+		}
+		return rec.Code
+	}
+
+	var open struct {
+		Open    bool             `json:"open"`
+		Session *calendar.Period `json:"session"`
+	}
+	if code := get("/calendar/open?at=2025-04-29T10:00:00%2B09:00", &open); code != http.StatusOK || !open.Open || open.Session.Board != "G1" {
+		t.Errorf("open: %d %+v", code, open)
+	}
+	var next calendar.Period
+	if code := get("/calendar/next-session?at=2025-04-29T18:30:00%2B09:00", &next); code != http.StatusOK || next.Name != "pre-open" {
+		t.Errorf("next session: %d %+v", code, next)
+	}
+	var prev map[string]string
+	if code := get("/calendar/previous-trading-day?date=20250428", &prev); code != http.StatusOK || prev["previousTradingDay"] != "20250425" {
+		t.Errorf("previous trading day: %d %v", code, prev)
+	}
+	if code := get("/calendar/open?at=tomorrow", &open); code != http.StatusBadRequest {
+		t.Errorf("invalid at: status %d", code)
+	}
+}
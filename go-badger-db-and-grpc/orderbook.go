@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+// 호가를 찾을 수 없는 경우의 에러
+var (
+	errStockNotFound = errors.New("stock not found")
+	errBoardNotFound = errors.New("board not found")
+)
+
+// limitPrice 의 보드별 호가 (10단계 배열, 장이 열리지 않은 보드는 null)
+type boardQuote struct {
+	Dt         string  `json:"dt"`
+	SellPrice  []int64 `json:"sellPrice"`
+	SellVolume []int64 `json:"sellVolume"`
+	BuyPrice   []int64 `json:"buyPrice"`
+	BuyVolume  []int64 `json:"buyVolume"`
+}
+
+// 저장된 종목 문서 중 호가에 필요한 필드
+type stockQuotes struct {
+	BoardID    string                 `json:"boardId"`
+	LimitPrice map[string]*boardQuote `json:"limitPrice"`
+}
+
+// PriceLevel 은 호가 한 단계입니다
+type PriceLevel struct {
+	Price            int64 `json:"price"`
+	Volume           int64 `json:"volume"`
+	CumulativeVolume int64 `json:"cumulativeVolume"` // 최우선 호가부터 이 단계까지의 잔량 합
+}
+
+// OrderBook 은 보드 하나의 호가와 파생 값입니다
+// 한쪽 호가가 없으면 최우선 호가, 스프레드, 중간 가격은 nil 입니다
+type OrderBook struct {
+	Key       string       `json:"key"`
+	Board     string       `json:"board"`
+	Time      string       `json:"time"`
+	Asks      []PriceLevel `json:"asks"` // 매도 호가 (낮은 가격부터)
+	Bids      []PriceLevel `json:"bids"` // 매수 호가 (높은 가격부터)
+	BestAsk   *int64       `json:"bestAsk"`
+	BestBid   *int64       `json:"bestBid"`
+	Spread    *int64       `json:"spread"`
+	MidPrice  *float64     `json:"midPrice"`
+	AskDepth  int64        `json:"askDepth"`
+	BidDepth  int64        `json:"bidDepth"`
+	Imbalance float64      `json:"imbalance"` // (매수 잔량 - 매도 잔량) / 전체 잔량, -1 ~ 1
+}
+
+// 가격/잔량 배열을 호가 단계로 바꿉니다
+// 가격이 0 인 단계는 비어 있는 호가이므로 건너뛰고, 두 배열 길이가 다르면 짧은 쪽에 맞춥니다
+func priceLevels(prices, volumes []int64) ([]PriceLevel, int64) {
+	levels := []PriceLevel{}
+	var cum int64
+	for i := 0; i < len(prices) && i < len(volumes); i++ {
+		if prices[i] <= 0 {
+			continue
+		}
+		cum += volumes[i]
+		levels = append(levels, PriceLevel{Price: prices[i], Volume: volumes[i], CumulativeVolume: cum})
+	}
+	return levels, cum
+}
+
+// 종목 문서에서 보드의 호가를 만듭니다 (board 를 비우면 종목의 boardId)
+func parseOrderBook(key, board string, value []byte) (*OrderBook, error) {
+	var doc stockQuotes
+	if err := json.Unmarshal(value, &doc); err != nil {
+		return nil, fmt.Errorf("invalid stock document: %w", err)
+	}
+	if board == "" {
+		board = doc.BoardID
+	}
+	q, ok := doc.LimitPrice[board]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errBoardNotFound, board)
+	}
+	if q == nil {
+		q = &boardQuote{}
+	}
+
+	book := &OrderBook{Key: key, Board: board, Time: q.Dt}
+	book.Asks, book.AskDepth = priceLevels(q.SellPrice, q.SellVolume)
+	book.Bids, book.BidDepth = priceLevels(q.BuyPrice, q.BuyVolume)
+	if len(book.Asks) > 0 {
+		book.BestAsk = &book.Asks[0].Price
+	}
+	if len(book.Bids) > 0 {
+		book.BestBid = &book.Bids[0].Price
+	}
+	if book.BestAsk != nil && book.BestBid != nil {
+		spread := *book.BestAsk - *book.BestBid
+		mid := float64(*book.BestAsk+*book.BestBid) / 2
+		book.Spread, book.MidPrice = &spread, &mid
+	}
+	if total := book.AskDepth + book.BidDepth; total > 0 {
+		book.Imbalance = float64(book.BidDepth-book.AskDepth) / float64(total)
+	}
+	return book, nil
+}
+
+// 키의 종목 문서를 읽어 호가를 만듭니다
+func loadOrderBook(key, board string) (*OrderBook, error) {
+	value, err := loadValue(key)
+	if err == badger.ErrKeyNotFound {
+		return nil, errStockNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseOrderBook(key, board, []byte(value))
+}
+
+// /stocks/{key}/orderbook?board=G1 : 보드의 호가와 최우선 호가, 스프레드, 누적 잔량, 불균형
+func orderBookHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := r.PathValue("key")
+	book, err := loadOrderBook(key, r.URL.Query().Get("board"))
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		code := http.StatusUnprocessableEntity
+		if errors.Is(err, errStockNotFound) || errors.Is(err, errBoardNotFound) {
+			code = http.StatusNotFound
+		}
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	w.Header().Set("Cache-Control", cacheControl(key, time.Now()))
+	json.NewEncoder(w).Encode(book)
+}
+
+func (s *stockServer) GetOrderBook(ctx context.Context, req *pb.OrderBookRequest) (*pb.OrderBook, error) {
+	book, err := loadOrderBook(req.Key, req.Board)
+	switch {
+	case errors.Is(err, errStockNotFound), errors.Is(err, errBoardNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// gRPC-Gateway 가 Cache-Control 헤더로 전달
+	grpc.SetHeader(ctx, metadata.Pairs("cache-control", cacheControl(req.Key, time.Now())))
+	return book.proto(), nil
+}
+
+func (b *OrderBook) proto() *pb.OrderBook {
+	levels := func(ls []PriceLevel) []*pb.PriceLevel {
+		out := make([]*pb.PriceLevel, len(ls))
+		for i, l := range ls {
+			out[i] = &pb.PriceLevel{Price: l.Price, Volume: l.Volume, CumulativeVolume: l.CumulativeVolume}
+		}
+		return out
+	}
+	return &pb.OrderBook{
+		Key:       b.Key,
+		Board:     b.Board,
+		Time:      b.Time,
+		Asks:      levels(b.Asks),
+		Bids:      levels(b.Bids),
+		BestAsk:   b.BestAsk,
+		BestBid:   b.BestBid,
+		Spread:    b.Spread,
+		MidPrice:  b.MidPrice,
+		AskDepth:  b.AskDepth,
+		BidDepth:  b.BidDepth,
+		Imbalance: b.Imbalance,
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+func TestParseOrderBook(t *testing.T) {
+	doc := `{"boardId": "G1", "limitPrice": {"G1": {
+		"dt": "2025-04-29T10:00:00",
+		"sellPrice": [100, 101, 102, 0], "sellVolume": [10, 20, 30, 0],
+		"buyPrice": [99, 98, 0], "buyVolume": [40, 50, 0]
+	}}}`
+	book, err := parseOrderBook("k", "", []byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Board != "G1" || len(book.Asks) != 3 || len(book.Bids) != 2 {
+		t.Fatalf("book = %+v", book)
+	}
+	if book.Asks[2].CumulativeVolume != 60 || book.Bids[1].CumulativeVolume != 90 {
+		t.Errorf("cumulative = %+v %+v", book.Asks, book.Bids)
+	}
+	if *book.BestAsk != 100 || *book.BestBid != 99 || *book.Spread != 1 || *book.MidPrice != 99.5 {
+		t.Errorf("best = %d/%d spread %d mid %v", *book.BestAsk, *book.BestBid, *book.Spread, *book.MidPrice)
+	}
+	if book.AskDepth != 60 || book.BidDepth != 90 || book.Imbalance != 0.2 {
+		t.Errorf("depth = %d/%d imbalance %v", book.AskDepth, book.BidDepth, book.Imbalance)
+	}
+}
+
+func TestParseOrderBookStoredBoards(t *testing.T) {
+	openTestDB(t)
+	initData()
+	value := testStockJSON(t)
+
+	// 매도 호가만 있는 보드: 매수 쪽 값과 스프레드, 중간 가격은 없음
+	book, err := parseOrderBook(testStockKey, "G1", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Asks) != 10 || len(book.Bids) != 0 || *book.BestAsk != 52700 || book.BestBid != nil || book.Spread != nil || book.MidPrice != nil {
+		t.Errorf("G1 = %+v", book)
+	}
+	if book.AskDepth != 3572 || book.Imbalance != -1 {
+		t.Errorf("G1 depth %d imbalance %v", book.AskDepth, book.Imbalance)
+	}
+
+	// null 호가 보드(G2, I1)와 0 으로 채워진 보드(G4, 종목의 boardId)는 빈 호가
+	for _, board := range []string{"G2", "I1", ""} {
+		book, err := parseOrderBook(testStockKey, board, value)
+		if err != nil {
+			t.Fatalf("%q: %v", board, err)
+		}
+		if len(book.Asks) != 0 || len(book.Bids) != 0 || book.BestAsk != nil || book.Imbalance != 0 {
+			t.Errorf("%q = %+v", board, book)
+		}
+	}
+
+	if _, err := parseOrderBook(testStockKey, "X9", value); !errors.Is(err, errBoardNotFound) {
+		t.Errorf("unknown board: %v", err)
+	}
+}
+
+func TestOrderBookEndpoints(t *testing.T) {
+	openTestDB(t)
+	initData()
+
+	// HTTP 엔드포인트
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stocks/{key}/orderbook", orderBookHandler)
+	for path, want := range map[string]int{
+		"/stocks/" + testStockKey + "/orderbook?board=G1": http.StatusOK,
+		"/stocks/" + testStockKey + "/orderbook?board=X9": http.StatusNotFound,
+		"/stocks/stock:20250428:NONE/orderbook":           http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status %d, want %d (%s)", path, rec.Code, want, rec.Body)
+		}
+	}
+
+	// gRPC-Gateway 를 거친 GetOrderBook
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterStockServiceServer(grpcServer, &stockServer{})
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gwHandler, err := newGatewayHandler(ctx, lis.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	gwHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/stocks/"+testStockKey+"/orderbook?board=G1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d, body %s", rec.Code, rec.Body)
+	}
+	var resp struct {
+		Board    string `json:"board"`
+		BestAsk  string `json:"bestAsk"` // protojson 은 int64 를 문자열로 인코딩
+		AskDepth string `json:"askDepth"`
+		Asks     []struct {
+			Price string `json:"price"`
+		} `json:"asks"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Board != "G1" || resp.BestAsk != "52700" || resp.AskDepth != "3572" || len(resp.Asks) != 10 {
+		t.Errorf("resp = %s", rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	gwHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/stocks/"+testStockKey+"/orderbook?board=X9", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown board: status %d", rec.Code)
+	}
+}
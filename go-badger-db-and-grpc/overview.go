@@ -0,0 +1,590 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	bpb "github.com/dgraph-io/badger/v4/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+// 요약을 보관할 최대 거래일 수 (넘으면 가장 오래 조회하지 않은 거래일을 지움)
+const maxOverviewDates = 5
+
+// 순위 목록 길이
+const (
+	defaultRankingLimit  = 20
+	defaultOverviewLimit = 10
+	maxRankingLimit      = 200
+)
+
+// 순위 기준
+const (
+	rankByChange     = "change"     // 전일 대비 (close - prevClose)
+	rankByChangeRate = "changeRate" // 전일 대비 등락률
+	rankByVolume     = "volume"     // totalAccumQuantity
+	rankByAmount     = "amount"     // totalAccumAmount
+)
+
+var rankFields = map[string]bool{rankByChange: true, rankByChangeRate: true, rankByVolume: true, rankByAmount: true}
+
+// StockSummary 는 순위 계산에 쓰는 종목 스냅샷 요약입니다
+type StockSummary struct {
+	Key           string  `json:"key"`
+	Code          string  `json:"code"`
+	ShortCode     string  `json:"shortCode"`
+	Market        string  `json:"market"`
+	StockGroup    string  `json:"stockGroupId"`
+	Close         int64   `json:"close"`
+	PrevClose     int64   `json:"prevClose"`
+	Change        int64   `json:"change"`
+	ChangeRate    float64 `json:"changeRate"` // %
+	Volume        int64   `json:"volume"`     // totalAccumQuantity
+	Amount        float64 `json:"amount"`     // totalAccumAmount
+	Halted        bool    `json:"halted"`
+	VIActive      bool    `json:"viActive"` // VI 발동 중 (해제 전)
+	VIKind        string  `json:"viKind,omitempty"`
+	VITriggerTime string  `json:"viTriggerTime,omitempty"`
+}
+
+// 전일 대비를 계산할 수 있는 종목 (체결과 전일 종가가 있음)
+func (s StockSummary) priced() bool {
+	return s.Close > 0 && s.PrevClose > 0
+}
+
+// 종목 문서를 요약합니다 (종목 스냅샷이 아니면 false)
+func summarizeStock(key string, raw []byte) (StockSummary, bool) {
+	var doc struct {
+		viState
+		ShortCode  string  `json:"shortCode"`
+		Market     string  `json:"market"`
+		StockGroup string  `json:"stockGroupId"`
+		Close      int64   `json:"close"`
+		PrevClose  int64   `json:"prevClose"`
+		Volume     int64   `json:"totalAccumQuantity"`
+		Amount     float64 `json:"totalAccumAmount"`
+		Halted     bool    `json:"tradingHalt"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil || doc.Code == "" {
+		return StockSummary{}, false
+	}
+	s := StockSummary{
+		Key:        key,
+		Code:       doc.Code,
+		ShortCode:  doc.ShortCode,
+		Market:     doc.Market,
+		StockGroup: doc.StockGroup,
+		Close:      doc.Close,
+		PrevClose:  doc.PrevClose,
+		Volume:     doc.Volume,
+		Amount:     doc.Amount,
+		Halted:     doc.Halted,
+		// 해제 시각이 없거나 마지막 발동보다 앞이면 발동 중 (시각은 HH:MM:SS.ffffff 문자열)
+		VIActive: doc.TriggerTime != "" && (doc.ClearTime == "" || doc.ClearTime < doc.TriggerTime),
+	}
+	if s.VIActive {
+		s.VIKind, s.VITriggerTime = doc.Kind, doc.TriggerTime
+	}
+	if s.priced() {
+		s.Change = s.Close - s.PrevClose
+		s.ChangeRate = float64(s.Change) / float64(s.PrevClose) * 100
+	}
+	return s, true
+}
+
+// 종목 키의 거래일 (stock:<YYYYMMDD>:<종목코드>)
+func stockKeyDate(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, stockKeyPrefix)
+	if !ok || len(rest) < 10 || rest[8] != ':' || !dateRe.MatchString(rest[:8]) {
+		return "", false
+	}
+	return rest[:8], true
+}
+
+// 거래일의 종목 스냅샷을 모두 읽어 요약합니다
+func scanSummaries(date string) (map[string]StockSummary, error) {
+	stocks := make(map[string]StockSummary)
+	prefix := []byte(stockKeyPrefix + date + ":")
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{Prefix: prefix, PrefetchValues: true, PrefetchSize: 100})
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			key := string(it.Item().Key())
+			if err := it.Item().Value(func(val []byte) error {
+				raw, err := decodeValue(val)
+				if err != nil {
+					return fmt.Errorf("%s: %w", key, err)
+				}
+				if s, ok := summarizeStock(key, raw); ok {
+					stocks[key] = s
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return stocks, err
+}
+
+// 거래일 하나의 요약과 계산한 순위
+type overviewDay struct {
+	ready   chan struct{}            // 처음 읽기를 마치면 닫힘
+	err     error                    // 처음 읽기에 실패한 이유
+	stocks  map[string]StockSummary  // 종목 키별 요약 (읽는 중이면 nil)
+	pending map[string]*StockSummary // 읽는 중에 받은 변경 (nil 이면 삭제)
+	ranked  map[string][]StockSummary
+	used    time.Time
+}
+
+// marketOverview 는 거래일별 종목 요약을 보관하고, Badger 변경 구독으로 바뀐 종목만 다시 요약합니다
+// 순위는 조건별로 계산해 두었다가 그 거래일의 종목이 바뀌면 지웁니다
+type marketOverview struct {
+	mu   sync.Mutex
+	days map[string]*overviewDay
+	now  func() time.Time
+}
+
+// 순위 집계 (nil 이면 요청마다 거래일을 다시 읽음)
+var overview *marketOverview
+
+func newMarketOverview() *marketOverview {
+	return &marketOverview{days: make(map[string]*overviewDay), now: time.Now}
+}
+
+// 거래일의 요약을 반환합니다 (처음이면 읽고, 다른 요청이 읽는 중이면 기다림)
+func (o *marketOverview) day(date string) (*overviewDay, error) {
+	o.mu.Lock()
+	d, ok := o.days[date]
+	if !ok {
+		d = &overviewDay{ready: make(chan struct{}), pending: make(map[string]*StockSummary)}
+		o.days[date] = d
+		o.evict()
+	}
+	d.used = o.now()
+	o.mu.Unlock()
+	if ok {
+		<-d.ready
+		return d, d.err
+	}
+
+	stocks, err := scanSummaries(date)
+	o.mu.Lock()
+	if err != nil {
+		d.err = err
+		delete(o.days, date)
+	} else {
+		// 읽는 동안 받은 변경이 더 최근 값
+		for key, s := range d.pending {
+			if s == nil {
+				delete(stocks, key)
+			} else {
+				stocks[key] = *s
+			}
+		}
+		d.stocks, d.pending, d.ranked = stocks, nil, make(map[string][]StockSummary)
+	}
+	o.mu.Unlock()
+	close(d.ready)
+	return d, err
+}
+
+// 보관한 거래일이 maxOverviewDates 를 넘으면 가장 오래 조회하지 않은 거래일을 지웁니다 (mu 를 잡은 상태에서 호출)
+func (o *marketOverview) evict() {
+	for len(o.days) > maxOverviewDates {
+		var oldest string
+		for date, d := range o.days {
+			if d.stocks != nil && (oldest == "" || d.used.Before(o.days[oldest].used)) {
+				oldest = date
+			}
+		}
+		if oldest == "" {
+			return // 모두 읽는 중
+		}
+		delete(o.days, oldest)
+	}
+}
+
+// 바뀐 종목 키의 요약을 갱신합니다 (보관하지 않은 거래일이면 무시, s 가 nil 이면 삭제)
+func (o *marketOverview) update(key string, s *StockSummary) {
+	date, ok := stockKeyDate(key)
+	if !ok {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	d, ok := o.days[date]
+	switch {
+	case !ok:
+	case d.stocks == nil:
+		d.pending[key] = s
+	default:
+		if s == nil {
+			delete(d.stocks, key)
+		} else {
+			d.stocks[key] = *s
+		}
+		clear(d.ranked)
+	}
+}
+
+// Badger 변경 구독으로 종목 요약을 갱신합니다 (ctx 가 끝날 때까지 실행, 확인용 키를 받으면 ready 를 닫음)
+func (o *marketOverview) watch(ctx context.Context, ready chan<- struct{}) error {
+	var once sync.Once
+	return db.Subscribe(ctx, func(kvs *badger.KVList) error {
+		for _, kv := range kvs.Kv {
+			key := string(kv.Key)
+			if key == cacheProbeKey {
+				once.Do(func() { close(ready) })
+				continue
+			}
+			// 삭제되었거나 종목 스냅샷이 아닌 값은 요약에서 뺌
+			raw, err := decodeValue(kv.Value)
+			if s, ok := summarizeStock(key, raw); err == nil && ok {
+				o.update(key, &s)
+			} else {
+				o.update(key, nil)
+			}
+		}
+		return nil
+	}, []bpb.Match{{Prefix: []byte(stockKeyPrefix)}, {Prefix: []byte(cacheProbeKey)}})
+}
+
+// 순위 집계를 만들고 변경 구독을 시작합니다
+func startOverview(ctx context.Context) error {
+	o := newMarketOverview()
+	ready := make(chan struct{})
+	go func() {
+		if err := o.watch(ctx, ready); err != nil && ctx.Err() == nil {
+			log.Printf("overview subscription stopped: %v", err)
+		}
+	}()
+	if err := waitSubscribed(ready); err != nil {
+		return err
+	}
+	overview = o
+	return nil
+}
+
+// rankingQuery 는 순위 조건입니다
+type rankingQuery struct {
+	Date       string
+	By         string // change, changeRate, volume, amount
+	Order      string // desc, asc
+	Market     string // 시장 구분 (비우면 전체)
+	StockGroup string // 증권 그룹 (비우면 전체)
+	Limit      int
+}
+
+// 조건을 확인하고 기본값을 채웁니다 (날짜를 비우면 가장 최근 거래일)
+func (q *rankingQuery) normalize(now time.Time) error {
+	if q.Date == "" {
+		day, _ := tradingCalendar.LatestTradingDay(now)
+		q.Date = day.Format("20060102")
+	} else if !dateRe.MatchString(q.Date) {
+		return fmt.Errorf("invalid date %q (YYYYMMDD)", q.Date)
+	}
+	if q.By == "" {
+		q.By = rankByChangeRate
+	}
+	if !rankFields[q.By] {
+		return fmt.Errorf("invalid by %q (change, changeRate, volume, amount)", q.By)
+	}
+	if q.Order == "" {
+		q.Order = "desc"
+	}
+	if q.Order != "desc" && q.Order != "asc" {
+		return fmt.Errorf("invalid order %q (desc, asc)", q.Order)
+	}
+	if q.Limit < 0 || q.Limit > maxRankingLimit {
+		return fmt.Errorf("limit must be between 1 and %d", maxRankingLimit)
+	}
+	if q.Limit == 0 {
+		q.Limit = defaultRankingLimit
+	}
+	return nil
+}
+
+// 순위 기준 값
+func rankValue(s StockSummary, by string) float64 {
+	switch by {
+	case rankByChange:
+		return float64(s.Change)
+	case rankByChangeRate:
+		return s.ChangeRate
+	case rankByVolume:
+		return float64(s.Volume)
+	default:
+		return s.Amount
+	}
+}
+
+// 조건에 맞는 종목을 기준 값 순서로 정렬합니다 (같으면 종목코드 순)
+// 전일 대비 순위에는 체결이나 전일 종가가 없는 종목을 넣지 않습니다
+func rankStocks(stocks map[string]StockSummary, q rankingQuery) []StockSummary {
+	ranked := []StockSummary{}
+	for _, s := range stocks {
+		if (q.Market != "" && s.Market != q.Market) || (q.StockGroup != "" && s.StockGroup != q.StockGroup) {
+			continue
+		}
+		if (q.By == rankByChange || q.By == rankByChangeRate) && !s.priced() {
+			continue
+		}
+		ranked = append(ranked, s)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := rankValue(ranked[i], q.By), rankValue(ranked[j], q.By)
+		if a != b {
+			return (a > b) == (q.Order == "desc")
+		}
+		return ranked[i].Code < ranked[j].Code
+	})
+	return ranked
+}
+
+// 조건에 맞는 전체 순위를 반환합니다 (계산해 둔 순위가 있으면 그대로, 반환한 목록은 수정하지 않아야 함)
+func (o *marketOverview) rankings(q rankingQuery) ([]StockSummary, error) {
+	d, err := o.day(q.Date)
+	if err != nil {
+		return nil, err
+	}
+	id := strings.Join([]string{q.By, q.Order, q.Market, q.StockGroup}, "|")
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if ranked, ok := d.ranked[id]; ok {
+		return ranked, nil
+	}
+	ranked := rankStocks(d.stocks, q)
+	d.ranked[id] = ranked
+	return ranked, nil
+}
+
+// VI 발동 중인 종목 (발동 시각 순)
+func (o *marketOverview) inVI(q rankingQuery) ([]StockSummary, error) {
+	d, err := o.day(q.Date)
+	if err != nil {
+		return nil, err
+	}
+	o.mu.Lock()
+	var stocks []StockSummary
+	for _, s := range d.stocks {
+		if s.VIActive && (q.Market == "" || s.Market == q.Market) && (q.StockGroup == "" || s.StockGroup == q.StockGroup) {
+			stocks = append(stocks, s)
+		}
+	}
+	o.mu.Unlock()
+	sort.Slice(stocks, func(i, j int) bool {
+		if stocks[i].VITriggerTime != stocks[j].VITriggerTime {
+			return stocks[i].VITriggerTime < stocks[j].VITriggerTime
+		}
+		return stocks[i].Code < stocks[j].Code
+	})
+	return stocks, nil
+}
+
+// 순위 집계 (시작하지 않았으면 이번 요청에만 쓰는 집계)
+func currentOverview() *marketOverview {
+	if overview != nil {
+		return overview
+	}
+	return newMarketOverview()
+}
+
+// Rankings 는 순위 한 목록입니다
+type Rankings struct {
+	Date   string         `json:"date"`
+	By     string         `json:"by"`
+	Order  string         `json:"order"`
+	Total  int            `json:"total"` // 조건에 맞는 종목 수
+	Stocks []StockSummary `json:"stocks"`
+}
+
+func loadRankings(q rankingQuery) (Rankings, error) {
+	ranked, err := currentOverview().rankings(q)
+	if err != nil {
+		return Rankings{}, err
+	}
+	return Rankings{Date: q.Date, By: q.By, Order: q.Order, Total: len(ranked), Stocks: ranked[:min(q.Limit, len(ranked))]}, nil
+}
+
+// MarketOverview 는 거래일의 시장 현황입니다
+type MarketOverview struct {
+	Date       string         `json:"date"`
+	Count      int            `json:"count"`
+	Advancing  int            `json:"advancing"`
+	Declining  int            `json:"declining"`
+	Unchanged  int            `json:"unchanged"`
+	Gainers    []StockSummary `json:"gainers"`
+	Losers     []StockSummary `json:"losers"`
+	MostTraded []StockSummary `json:"mostTraded"` // totalAccumAmount 순
+	InVI       []StockSummary `json:"inVI"`       // VI 발동 중
+}
+
+func loadMarketOverview(q rankingQuery) (MarketOverview, error) {
+	o := currentOverview()
+	m := MarketOverview{Date: q.Date}
+	top := func(by, order string) ([]StockSummary, error) {
+		rq := q
+		rq.By, rq.Order = by, order
+		return o.rankings(rq)
+	}
+
+	gainers, err := top(rankByChangeRate, "desc")
+	if err != nil {
+		return m, err
+	}
+	for _, s := range gainers {
+		switch {
+		case s.Change > 0:
+			m.Advancing++
+		case s.Change < 0:
+			m.Declining++
+		default:
+			m.Unchanged++
+		}
+	}
+	losers, err := top(rankByChangeRate, "asc")
+	if err != nil {
+		return m, err
+	}
+	traded, err := top(rankByAmount, "desc")
+	if err != nil {
+		return m, err
+	}
+	if m.InVI, err = o.inVI(q); err != nil {
+		return m, err
+	}
+	if m.InVI == nil {
+		m.InVI = []StockSummary{}
+	}
+
+	// 상승/하락 목록에는 오르거나 내린 종목만
+	m.Count = len(traded)
+	m.Gainers = gainers[:min(q.Limit, m.Advancing)]
+	m.Losers = losers[:min(q.Limit, m.Declining)]
+	m.MostTraded = traded[:min(q.Limit, len(traded))]
+	return m, nil
+}
+
+// 쿼리 파라미터의 순위 조건
+func rankingQueryParams(r *http.Request, defaultLimit int) (rankingQuery, error) {
+	v := r.URL.Query()
+	q := rankingQuery{Date: v.Get("date"), By: v.Get("by"), Order: v.Get("order"), Market: v.Get("market"), StockGroup: v.Get("stockGroupId"), Limit: defaultLimit}
+	if limit := v.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return q, fmt.Errorf("invalid limit %q", limit)
+		}
+		q.Limit = n
+	}
+	return q, q.normalize(time.Now())
+}
+
+// /market/rankings?date=20250429&by=amount&order=desc&market=S&stockGroupId=ST&limit=20 : 종목 순위
+func rankingsHandler(w http.ResponseWriter, r *http.Request) {
+	marketHandler(w, r, defaultRankingLimit, func(q rankingQuery) (interface{}, error) { return loadRankings(q) })
+}
+
+// /market/overview?date=20250429&market=S&limit=10 : 상승/하락 상위, 거래대금 상위, VI 발동 중인 종목
+func marketOverviewHandler(w http.ResponseWriter, r *http.Request) {
+	marketHandler(w, r, defaultOverviewLimit, func(q rankingQuery) (interface{}, error) { return loadMarketOverview(q) })
+}
+
+func marketHandler(w http.ResponseWriter, r *http.Request, defaultLimit int, load func(rankingQuery) (interface{}, error)) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	q, err := rankingQueryParams(r, defaultLimit)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	resp, err := load(q)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to load rankings"})
+		return
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s StockSummary) proto() *pb.StockSummary {
+	return &pb.StockSummary{
+		Key:           s.Key,
+		Code:          s.Code,
+		ShortCode:     s.ShortCode,
+		Market:        s.Market,
+		StockGroupId:  s.StockGroup,
+		Close:         s.Close,
+		PrevClose:     s.PrevClose,
+		Change:        s.Change,
+		ChangeRate:    s.ChangeRate,
+		Volume:        s.Volume,
+		Amount:        s.Amount,
+		Halted:        s.Halted,
+		ViActive:      s.VIActive,
+		ViKind:        s.VIKind,
+		ViTriggerTime: s.VITriggerTime,
+	}
+}
+
+func summariesProto(stocks []StockSummary) []*pb.StockSummary {
+	out := make([]*pb.StockSummary, len(stocks))
+	for i, s := range stocks {
+		out[i] = s.proto()
+	}
+	return out
+}
+
+func (s *stockServer) GetRankings(ctx context.Context, req *pb.RankingsRequest) (*pb.Rankings, error) {
+	q := rankingQuery{Date: req.Date, By: req.By, Order: req.Order, Market: req.Market, StockGroup: req.StockGroupId, Limit: int(req.Limit)}
+	if err := q.normalize(time.Now()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	r, err := loadRankings(q)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Rankings{Date: r.Date, By: r.By, Order: r.Order, Total: int32(r.Total), Stocks: summariesProto(r.Stocks)}, nil
+}
+
+func (s *stockServer) GetMarketOverview(ctx context.Context, req *pb.MarketOverviewRequest) (*pb.MarketOverview, error) {
+	q := rankingQuery{Date: req.Date, Market: req.Market, StockGroup: req.StockGroupId, Limit: int(req.Limit)}
+	if q.Limit == 0 {
+		q.Limit = defaultOverviewLimit
+	}
+	if err := q.normalize(time.Now()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	m, err := loadMarketOverview(q)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.MarketOverview{
+		Date:       m.Date,
+		Count:      int32(m.Count),
+		Advancing:  int32(m.Advancing),
+		Declining:  int32(m.Declining),
+		Unchanged:  int32(m.Unchanged),
+		Gainers:    summariesProto(m.Gainers),
+		Losers:     summariesProto(m.Losers),
+		MostTraded: summariesProto(m.MostTraded),
+		InVi:       summariesProto(m.InVI),
+	}, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// 순위 계산에 쓰는 필드만 담은 종목 문서를 저장합니다
+func putRankedStock(t *testing.T, code, market, group string, close, prevClose, volume int64, amount float64, viTrigger, viClear string) {
+	t.Helper()
+	doc := fmt.Sprintf(`{"code":%q,"baseDate":"20250429","market":%q,"stockGroupId":%q,"close":%d,"prevClose":%d,"totalAccumQuantity":%d,"totalAccumAmount":%.1f,"viKind":"2","viTriggerTime":%q,"viClearTime":%q}`,
+		code, market, group, close, prevClose, volume, amount, viTrigger, viClear)
+	err := db.Update(func(txn *badger.Txn) error {
+		return putValue(txn, []byte("stock:20250429:"+code), []byte(doc))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func startTestOverview(t *testing.T) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		overview = nil
+	})
+	if err := startOverview(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func rankedCodes(stocks []StockSummary) string {
+	codes := make([]string, len(stocks))
+	for i, s := range stocks {
+		codes[i] = s.Code
+	}
+	return fmt.Sprint(codes)
+}
+
+func seedRankedStocks(t *testing.T) {
+	putRankedStock(t, "KR7000000001", "S", "ST", 11000, 10000, 100, 1_100_000, "", "")                    // +10%
+	putRankedStock(t, "KR7000000002", "S", "ST", 9500, 10000, 500, 4_750_000, "10:00:00.0", "")           // -5%, VI 발동 중
+	putRankedStock(t, "KR7000000003", "K", "ST", 20000, 20000, 50, 1_000_000, "09:10:00.0", "09:12:00.0") // 보합, VI 해제
+	putRankedStock(t, "KR7000000004", "S", "EF", 10200, 10000, 1000, 10_200_000, "", "")                  // +2%
+	putRankedStock(t, "KR7000000005", "K", "ST", 0, 5000, 0, 0, "", "")                                   // 체결 없음
+}
+
+func TestRankings(t *testing.T) {
+	openTestDB(t)
+	seedRankedStocks(t)
+	startTestOverview(t)
+
+	cases := []struct {
+		q    rankingQuery
+		want string
+	}{
+		{rankingQuery{Date: "20250429"}, "[KR7000000001 KR7000000004 KR7000000003 KR7000000002]"},
+		{rankingQuery{Date: "20250429", Order: "asc", Limit: 2}, "[KR7000000002 KR7000000003]"},
+		{rankingQuery{Date: "20250429", By: "amount"}, "[KR7000000004 KR7000000002 KR7000000001 KR7000000003 KR7000000005]"},
+		{rankingQuery{Date: "20250429", By: "volume", Market: "K"}, "[KR7000000003 KR7000000005]"},
+		{rankingQuery{Date: "20250429", By: "change", StockGroup: "ST"}, "[KR7000000001 KR7000000003 KR7000000002]"},
+	}
+	for _, c := range cases {
+		if err := c.q.normalize(time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		r, err := loadRankings(c.q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rankedCodes(r.Stocks); got != c.want {
+			t.Errorf("%+v: %s, want %s", c.q, got, c.want)
+		}
+	}
+
+	for _, q := range []rankingQuery{{By: "price"}, {Order: "up"}, {Date: "2025-04-29"}, {Limit: 1000}} {
+		if err := q.normalize(time.Now()); err == nil {
+			t.Errorf("%+v: expected error", q)
+		}
+	}
+}
+
+func TestMarketOverviewRefreshOnWrite(t *testing.T) {
+	openTestDB(t)
+	seedRankedStocks(t)
+	startTestOverview(t)
+
+	q := rankingQuery{Date: "20250429", Limit: 10}
+	m, err := loadMarketOverview(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Count != 5 || m.Advancing != 2 || m.Declining != 1 || m.Unchanged != 1 {
+		t.Errorf("overview = %+v", m)
+	}
+	if rankedCodes(m.Gainers) != "[KR7000000001 KR7000000004]" || rankedCodes(m.Losers) != "[KR7000000002]" {
+		t.Errorf("gainers = %s, losers = %s", rankedCodes(m.Gainers), rankedCodes(m.Losers))
+	}
+	if rankedCodes(m.MostTraded[:1]) != "[KR7000000004]" || rankedCodes(m.InVI) != "[KR7000000002]" {
+		t.Errorf("most traded = %s, in VI = %s", rankedCodes(m.MostTraded), rankedCodes(m.InVI))
+	}
+
+	// 쓰기가 구독으로 반영되면 순위가 바뀜 (VI 해제, 하락 종목이 상한가)
+	putRankedStock(t, "KR7000000002", "S", "ST", 13000, 10000, 900, 11_700_000, "10:00:00.0", "10:02:00.0")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		m, err = loadMarketOverview(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rankedCodes(m.Gainers[:1]) == "[KR7000000002]" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("overview not refreshed: %+v", m)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if m.Declining != 0 || len(m.InVI) != 0 || rankedCodes(m.MostTraded[:1]) != "[KR7000000002]" {
+		t.Errorf("refreshed overview = %+v", m)
+	}
+}
+
+func TestMarketHandlers(t *testing.T) {
+	openTestDB(t)
+	seedRankedStocks(t)
+
+	// 집계를 시작하지 않아도 요청마다 읽어 계산
+	rec := httptest.NewRecorder()
+	rankingsHandler(rec, httptest.NewRequest(http.MethodGet, "/market/rankings?date=20250429&by=amount&market=S&limit=1", nil))
+	var r Rankings
+	if err := json.Unmarshal(rec.Body.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Total != 3 || rankedCodes(r.Stocks) != "[KR7000000004]" {
+		t.Errorf("rankings = %+v", r)
+	}
+
+	rec = httptest.NewRecorder()
+	marketOverviewHandler(rec, httptest.NewRequest(http.MethodGet, "/market/overview?date=20250429&stockGroupId=EF", nil))
+	var m MarketOverview
+	if err := json.Unmarshal(rec.Body.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.Count != 1 || m.Advancing != 1 || len(m.InVI) != 0 {
+		t.Errorf("overview = %+v", m)
+	}
+
+	rec = httptest.NewRecorder()
+	rankingsHandler(rec, httptest.NewRequest(http.MethodGet, "/market/rankings?limit=0", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("limit=0: status = %d", rec.Code)
+	}
+}
@@ -7,6 +7,7 @@
 package generated
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -24,6 +25,7 @@ const (
 type StockRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Adjusted      bool                   `protobuf:"varint,2,opt,name=adjusted,proto3" json:"adjusted,omitempty"` // prevClose, base 를 기업 행위로 수정
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,6 +67,13 @@ func (x *StockRequest) GetKey() string {
 	return ""
 }
 
+func (x *StockRequest) GetAdjusted() bool {
+	if x != nil {
+		return x.Adjusted
+	}
+	return false
+}
+
 type StockMaster struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
@@ -109,17 +118,1678 @@ func (x *StockMaster) GetValue() string {
 	return ""
 }
 
-var File_proto_get_stockmaster_proto protoreflect.FileDescriptor
+type OrderBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Board         string                 `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"` // limitPrice 의 보드 ID (비우면 종목의 boardId)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_get_stockmaster_proto_rawDesc = "" +
-	"\n" +
-	"\x1bproto/get_stockmaster.proto\x12\x05proto\" \n" +
-	"\fStockRequest\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\"#\n" +
-	"\vStockMaster\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\tR\x05value2I\n" +
-	"\fStockService\x129\n" +
-	"\x0eGetStockMaster\x12\x13.proto.StockRequest\x1a\x12.proto.StockMasterB\x11Z\x0fproto/generatedb\x06proto3"
+func (x *OrderBookRequest) Reset() {
+	*x = OrderBookRequest{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookRequest) ProtoMessage() {}
+
+func (x *OrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookRequest.ProtoReflect.Descriptor instead.
+func (*OrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderBookRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *OrderBookRequest) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+// 호가 한 단계
+type PriceLevel struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Price            int64                  `protobuf:"varint,1,opt,name=price,proto3" json:"price,omitempty"`
+	Volume           int64                  `protobuf:"varint,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	CumulativeVolume int64                  `protobuf:"varint,3,opt,name=cumulative_volume,json=cumulativeVolume,proto3" json:"cumulative_volume,omitempty"` // 최우선 호가부터 이 단계까지의 잔량 합
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PriceLevel) Reset() {
+	*x = PriceLevel{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLevel) ProtoMessage() {}
+
+func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PriceLevel) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetCumulativeVolume() int64 {
+	if x != nil {
+		return x.CumulativeVolume
+	}
+	return 0
+}
+
+type OrderBook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Board string                 `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	Time  string                 `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	Asks  []*PriceLevel          `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"` // 매도 호가 (낮은 가격부터)
+	Bids  []*PriceLevel          `protobuf:"bytes,5,rep,name=bids,proto3" json:"bids,omitempty"` // 매수 호가 (높은 가격부터)
+	// 한쪽 호가가 없으면 아래 값은 비어 있음
+	BestAsk       *int64   `protobuf:"varint,6,opt,name=best_ask,json=bestAsk,proto3,oneof" json:"best_ask,omitempty"`
+	BestBid       *int64   `protobuf:"varint,7,opt,name=best_bid,json=bestBid,proto3,oneof" json:"best_bid,omitempty"`
+	Spread        *int64   `protobuf:"varint,8,opt,name=spread,proto3,oneof" json:"spread,omitempty"`
+	MidPrice      *float64 `protobuf:"fixed64,9,opt,name=mid_price,json=midPrice,proto3,oneof" json:"mid_price,omitempty"`
+	AskDepth      int64    `protobuf:"varint,10,opt,name=ask_depth,json=askDepth,proto3" json:"ask_depth,omitempty"`
+	BidDepth      int64    `protobuf:"varint,11,opt,name=bid_depth,json=bidDepth,proto3" json:"bid_depth,omitempty"`
+	Imbalance     float64  `protobuf:"fixed64,12,opt,name=imbalance,proto3" json:"imbalance,omitempty"` // (매수 잔량 - 매도 잔량) / 전체 잔량, -1 ~ 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBook) Reset() {
+	*x = OrderBook{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBook) ProtoMessage() {}
+
+func (x *OrderBook) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBook.ProtoReflect.Descriptor instead.
+func (*OrderBook) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OrderBook) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *OrderBook) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *OrderBook) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *OrderBook) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBook) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBook) GetBestAsk() int64 {
+	if x != nil && x.BestAsk != nil {
+		return *x.BestAsk
+	}
+	return 0
+}
+
+func (x *OrderBook) GetBestBid() int64 {
+	if x != nil && x.BestBid != nil {
+		return *x.BestBid
+	}
+	return 0
+}
+
+func (x *OrderBook) GetSpread() int64 {
+	if x != nil && x.Spread != nil {
+		return *x.Spread
+	}
+	return 0
+}
+
+func (x *OrderBook) GetMidPrice() float64 {
+	if x != nil && x.MidPrice != nil {
+		return *x.MidPrice
+	}
+	return 0
+}
+
+func (x *OrderBook) GetAskDepth() int64 {
+	if x != nil {
+		return x.AskDepth
+	}
+	return 0
+}
+
+func (x *OrderBook) GetBidDepth() int64 {
+	if x != nil {
+		return x.BidDepth
+	}
+	return 0
+}
+
+func (x *OrderBook) GetImbalance() float64 {
+	if x != nil {
+		return x.Imbalance
+	}
+	return 0
+}
+
+type CandlesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Isin          string                 `protobuf:"bytes,1,opt,name=isin,proto3" json:"isin,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`          // RFC3339 또는 YYYYMMDD (비우면 to 가 속한 날의 0시)
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`              // RFC3339 또는 YYYYMMDD (날짜는 그날 끝까지 포함, 비우면 지금)
+	Interval      string                 `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`  // 1m, 5m, 1d (기본 1m)
+	Adjusted      bool                   `protobuf:"varint,5,opt,name=adjusted,proto3" json:"adjusted,omitempty"` // 기업 행위로 수정한 가격과 거래량
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CandlesRequest) Reset() {
+	*x = CandlesRequest{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CandlesRequest) ProtoMessage() {}
+
+func (x *CandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CandlesRequest.ProtoReflect.Descriptor instead.
+func (*CandlesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CandlesRequest) GetIsin() string {
+	if x != nil {
+		return x.Isin
+	}
+	return ""
+}
+
+func (x *CandlesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *CandlesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *CandlesRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *CandlesRequest) GetAdjusted() bool {
+	if x != nil {
+		return x.Adjusted
+	}
+	return false
+}
+
+// 간격 하나의 시가/고가/저가/종가와 거래량
+type Candle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          string                 `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"` // 간격 시작 시각 (RFC3339)
+	Open          int64                  `protobuf:"varint,2,opt,name=open,proto3" json:"open,omitempty"`
+	High          int64                  `protobuf:"varint,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low           int64                  `protobuf:"varint,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close         int64                  `protobuf:"varint,5,opt,name=close,proto3" json:"close,omitempty"`
+	Volume        int64                  `protobuf:"varint,6,opt,name=volume,proto3" json:"volume,omitempty"`
+	Halted        bool                   `protobuf:"varint,7,opt,name=halted,proto3" json:"halted,omitempty"` // 거래 정지 중이었던 간격
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Candle) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *Candle) GetOpen() int64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Candle) GetHigh() int64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Candle) GetLow() int64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Candle) GetClose() int64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *Candle) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Candle) GetHalted() bool {
+	if x != nil {
+		return x.Halted
+	}
+	return false
+}
+
+type Candles struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Isin          string                 `protobuf:"bytes,1,opt,name=isin,proto3" json:"isin,omitempty"`
+	Interval      string                 `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Candles       []*Candle              `protobuf:"bytes,3,rep,name=candles,proto3" json:"candles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Candles) Reset() {
+	*x = Candles{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candles) ProtoMessage() {}
+
+func (x *Candles) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candles.ProtoReflect.Descriptor instead.
+func (*Candles) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Candles) GetIsin() string {
+	if x != nil {
+		return x.Isin
+	}
+	return ""
+}
+
+func (x *Candles) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *Candles) GetCandles() []*Candle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+type ListVIEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Isin          string                 `protobuf:"bytes,1,opt,name=isin,proto3" json:"isin,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"` // YYYYMMDD (비우면 한국 시간 오늘)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVIEventsRequest) Reset() {
+	*x = ListVIEventsRequest{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVIEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVIEventsRequest) ProtoMessage() {}
+
+func (x *ListVIEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVIEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListVIEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListVIEventsRequest) GetIsin() string {
+	if x != nil {
+		return x.Isin
+	}
+	return ""
+}
+
+func (x *ListVIEventsRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type WatchVIEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Isin          string                 `protobuf:"bytes,1,opt,name=isin,proto3" json:"isin,omitempty"` // 비우면 모든 종목
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchVIEventsRequest) Reset() {
+	*x = WatchVIEventsRequest{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchVIEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchVIEventsRequest) ProtoMessage() {}
+
+func (x *WatchVIEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchVIEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchVIEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchVIEventsRequest) GetIsin() string {
+	if x != nil {
+		return x.Isin
+	}
+	return ""
+}
+
+// VI 발동 또는 해제 한 건
+type VIEvent struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Isin             string                 `protobuf:"bytes,1,opt,name=isin,proto3" json:"isin,omitempty"`
+	Date             string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Type             string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                            // trigger, clear
+	Time             string                 `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`                            // RFC3339
+	Kind             string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`                            // viKind
+	ApplyCode        string                 `protobuf:"bytes,6,opt,name=apply_code,json=applyCode,proto3" json:"apply_code,omitempty"` // viApplyCode
+	TriggerCount     int64                  `protobuf:"varint,7,opt,name=trigger_count,json=triggerCount,proto3" json:"trigger_count,omitempty"`
+	TriggerPrice     int64                  `protobuf:"varint,8,opt,name=trigger_price,json=triggerPrice,proto3" json:"trigger_price,omitempty"`
+	StaticBasePrice  int64                  `protobuf:"varint,9,opt,name=static_base_price,json=staticBasePrice,proto3" json:"static_base_price,omitempty"`
+	DynamicBasePrice int64                  `protobuf:"varint,10,opt,name=dynamic_base_price,json=dynamicBasePrice,proto3" json:"dynamic_base_price,omitempty"`
+	StaticGapRate    float64                `protobuf:"fixed64,11,opt,name=static_gap_rate,json=staticGapRate,proto3" json:"static_gap_rate,omitempty"`
+	DynamicGapRate   float64                `protobuf:"fixed64,12,opt,name=dynamic_gap_rate,json=dynamicGapRate,proto3" json:"dynamic_gap_rate,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *VIEvent) Reset() {
+	*x = VIEvent{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VIEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VIEvent) ProtoMessage() {}
+
+func (x *VIEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VIEvent.ProtoReflect.Descriptor instead.
+func (*VIEvent) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VIEvent) GetIsin() string {
+	if x != nil {
+		return x.Isin
+	}
+	return ""
+}
+
+func (x *VIEvent) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *VIEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VIEvent) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *VIEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *VIEvent) GetApplyCode() string {
+	if x != nil {
+		return x.ApplyCode
+	}
+	return ""
+}
+
+func (x *VIEvent) GetTriggerCount() int64 {
+	if x != nil {
+		return x.TriggerCount
+	}
+	return 0
+}
+
+func (x *VIEvent) GetTriggerPrice() int64 {
+	if x != nil {
+		return x.TriggerPrice
+	}
+	return 0
+}
+
+func (x *VIEvent) GetStaticBasePrice() int64 {
+	if x != nil {
+		return x.StaticBasePrice
+	}
+	return 0
+}
+
+func (x *VIEvent) GetDynamicBasePrice() int64 {
+	if x != nil {
+		return x.DynamicBasePrice
+	}
+	return 0
+}
+
+func (x *VIEvent) GetStaticGapRate() float64 {
+	if x != nil {
+		return x.StaticGapRate
+	}
+	return 0
+}
+
+func (x *VIEvent) GetDynamicGapRate() float64 {
+	if x != nil {
+		return x.DynamicGapRate
+	}
+	return 0
+}
+
+type VIEvents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Isin          string                 `protobuf:"bytes,1,opt,name=isin,proto3" json:"isin,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Events        []*VIEvent             `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VIEvents) Reset() {
+	*x = VIEvents{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VIEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VIEvents) ProtoMessage() {}
+
+func (x *VIEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VIEvents.ProtoReflect.Descriptor instead.
+func (*VIEvents) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VIEvents) GetIsin() string {
+	if x != nil {
+		return x.Isin
+	}
+	return ""
+}
+
+func (x *VIEvents) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *VIEvents) GetEvents() []*VIEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// 거래일 종목 스냅샷 요약
+type StockSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	ShortCode     string                 `protobuf:"bytes,3,opt,name=short_code,json=shortCode,proto3" json:"short_code,omitempty"`
+	Market        string                 `protobuf:"bytes,4,opt,name=market,proto3" json:"market,omitempty"`
+	StockGroupId  string                 `protobuf:"bytes,5,opt,name=stock_group_id,json=stockGroupId,proto3" json:"stock_group_id,omitempty"`
+	Close         int64                  `protobuf:"varint,6,opt,name=close,proto3" json:"close,omitempty"`
+	PrevClose     int64                  `protobuf:"varint,7,opt,name=prev_close,json=prevClose,proto3" json:"prev_close,omitempty"`
+	Change        int64                  `protobuf:"varint,8,opt,name=change,proto3" json:"change,omitempty"`                            // close - prevClose
+	ChangeRate    float64                `protobuf:"fixed64,9,opt,name=change_rate,json=changeRate,proto3" json:"change_rate,omitempty"` // 전일 대비 등락률 (%)
+	Volume        int64                  `protobuf:"varint,10,opt,name=volume,proto3" json:"volume,omitempty"`                           // totalAccumQuantity
+	Amount        float64                `protobuf:"fixed64,11,opt,name=amount,proto3" json:"amount,omitempty"`                          // totalAccumAmount
+	Halted        bool                   `protobuf:"varint,12,opt,name=halted,proto3" json:"halted,omitempty"`
+	ViActive      bool                   `protobuf:"varint,13,opt,name=vi_active,json=viActive,proto3" json:"vi_active,omitempty"` // VI 발동 중 (해제 전)
+	ViKind        string                 `protobuf:"bytes,14,opt,name=vi_kind,json=viKind,proto3" json:"vi_kind,omitempty"`
+	ViTriggerTime string                 `protobuf:"bytes,15,opt,name=vi_trigger_time,json=viTriggerTime,proto3" json:"vi_trigger_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockSummary) Reset() {
+	*x = StockSummary{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockSummary) ProtoMessage() {}
+
+func (x *StockSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockSummary.ProtoReflect.Descriptor instead.
+func (*StockSummary) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StockSummary) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StockSummary) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *StockSummary) GetShortCode() string {
+	if x != nil {
+		return x.ShortCode
+	}
+	return ""
+}
+
+func (x *StockSummary) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *StockSummary) GetStockGroupId() string {
+	if x != nil {
+		return x.StockGroupId
+	}
+	return ""
+}
+
+func (x *StockSummary) GetClose() int64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *StockSummary) GetPrevClose() int64 {
+	if x != nil {
+		return x.PrevClose
+	}
+	return 0
+}
+
+func (x *StockSummary) GetChange() int64 {
+	if x != nil {
+		return x.Change
+	}
+	return 0
+}
+
+func (x *StockSummary) GetChangeRate() float64 {
+	if x != nil {
+		return x.ChangeRate
+	}
+	return 0
+}
+
+func (x *StockSummary) GetVolume() int64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *StockSummary) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *StockSummary) GetHalted() bool {
+	if x != nil {
+		return x.Halted
+	}
+	return false
+}
+
+func (x *StockSummary) GetViActive() bool {
+	if x != nil {
+		return x.ViActive
+	}
+	return false
+}
+
+func (x *StockSummary) GetViKind() string {
+	if x != nil {
+		return x.ViKind
+	}
+	return ""
+}
+
+func (x *StockSummary) GetViTriggerTime() string {
+	if x != nil {
+		return x.ViTriggerTime
+	}
+	return ""
+}
+
+type RankingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`                                       // YYYYMMDD (비우면 가장 최근 거래일)
+	By            string                 `protobuf:"bytes,2,opt,name=by,proto3" json:"by,omitempty"`                                           // change, changeRate(기본), volume, amount
+	Order         string                 `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`                                     // desc(기본), asc
+	Market        string                 `protobuf:"bytes,4,opt,name=market,proto3" json:"market,omitempty"`                                   // 시장 구분 (비우면 전체)
+	StockGroupId  string                 `protobuf:"bytes,5,opt,name=stock_group_id,json=stockGroupId,proto3" json:"stock_group_id,omitempty"` // 증권 그룹 (비우면 전체)
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                                    // 기본 20, 최대 200
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankingsRequest) Reset() {
+	*x = RankingsRequest{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankingsRequest) ProtoMessage() {}
+
+func (x *RankingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankingsRequest.ProtoReflect.Descriptor instead.
+func (*RankingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RankingsRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *RankingsRequest) GetBy() string {
+	if x != nil {
+		return x.By
+	}
+	return ""
+}
+
+func (x *RankingsRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *RankingsRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *RankingsRequest) GetStockGroupId() string {
+	if x != nil {
+		return x.StockGroupId
+	}
+	return ""
+}
+
+func (x *RankingsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Rankings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	By            string                 `protobuf:"bytes,2,opt,name=by,proto3" json:"by,omitempty"`
+	Order         string                 `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"` // 조건에 맞는 종목 수
+	Stocks        []*StockSummary        `protobuf:"bytes,5,rep,name=stocks,proto3" json:"stocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rankings) Reset() {
+	*x = Rankings{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rankings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rankings) ProtoMessage() {}
+
+func (x *Rankings) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rankings.ProtoReflect.Descriptor instead.
+func (*Rankings) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Rankings) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Rankings) GetBy() string {
+	if x != nil {
+		return x.By
+	}
+	return ""
+}
+
+func (x *Rankings) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *Rankings) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Rankings) GetStocks() []*StockSummary {
+	if x != nil {
+		return x.Stocks
+	}
+	return nil
+}
+
+type MarketOverviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Market        string                 `protobuf:"bytes,2,opt,name=market,proto3" json:"market,omitempty"`
+	StockGroupId  string                 `protobuf:"bytes,3,opt,name=stock_group_id,json=stockGroupId,proto3" json:"stock_group_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"` // 목록별 종목 수 (기본 10, 최대 200)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketOverviewRequest) Reset() {
+	*x = MarketOverviewRequest{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketOverviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketOverviewRequest) ProtoMessage() {}
+
+func (x *MarketOverviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketOverviewRequest.ProtoReflect.Descriptor instead.
+func (*MarketOverviewRequest) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MarketOverviewRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *MarketOverviewRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *MarketOverviewRequest) GetStockGroupId() string {
+	if x != nil {
+		return x.StockGroupId
+	}
+	return ""
+}
+
+func (x *MarketOverviewRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type MarketOverview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Advancing     int32                  `protobuf:"varint,3,opt,name=advancing,proto3" json:"advancing,omitempty"`
+	Declining     int32                  `protobuf:"varint,4,opt,name=declining,proto3" json:"declining,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,5,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Gainers       []*StockSummary        `protobuf:"bytes,6,rep,name=gainers,proto3" json:"gainers,omitempty"`
+	Losers        []*StockSummary        `protobuf:"bytes,7,rep,name=losers,proto3" json:"losers,omitempty"`
+	MostTraded    []*StockSummary        `protobuf:"bytes,8,rep,name=most_traded,json=mostTraded,proto3" json:"most_traded,omitempty"` // totalAccumAmount 순
+	InVi          []*StockSummary        `protobuf:"bytes,9,rep,name=in_vi,json=inVi,proto3" json:"in_vi,omitempty"`                   // VI 발동 중
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketOverview) Reset() {
+	*x = MarketOverview{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketOverview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketOverview) ProtoMessage() {}
+
+func (x *MarketOverview) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketOverview.ProtoReflect.Descriptor instead.
+func (*MarketOverview) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MarketOverview) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *MarketOverview) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *MarketOverview) GetAdvancing() int32 {
+	if x != nil {
+		return x.Advancing
+	}
+	return 0
+}
+
+func (x *MarketOverview) GetDeclining() int32 {
+	if x != nil {
+		return x.Declining
+	}
+	return 0
+}
+
+func (x *MarketOverview) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *MarketOverview) GetGainers() []*StockSummary {
+	if x != nil {
+		return x.Gainers
+	}
+	return nil
+}
+
+func (x *MarketOverview) GetLosers() []*StockSummary {
+	if x != nil {
+		return x.Losers
+	}
+	return nil
+}
+
+func (x *MarketOverview) GetMostTraded() []*StockSummary {
+	if x != nil {
+		return x.MostTraded
+	}
+	return nil
+}
+
+func (x *MarketOverview) GetInVi() []*StockSummary {
+	if x != nil {
+		return x.InVi
+	}
+	return nil
+}
+
+type DiffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"` // 버전 번호 또는 RFC3339 시각 (비우면 to 의 이전 버전)
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`     // 버전 번호 또는 RFC3339 시각 (비우면 최신 버전)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffRequest) Reset() {
+	*x = DiffRequest{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRequest) ProtoMessage() {}
+
+func (x *DiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRequest.ProtoReflect.Descriptor instead.
+func (*DiffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DiffRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// 값의 버전 하나
+type StockVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       uint64                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // Badger 커밋 버전
+	Time          string                 `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`        // 저장 시각 (RFC3339, 모르면 빈 값)
+	Deleted       bool                   `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockVersion) Reset() {
+	*x = StockVersion{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockVersion) ProtoMessage() {}
+
+func (x *StockVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockVersion.ProtoReflect.Descriptor instead.
+func (*StockVersion) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StockVersion) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *StockVersion) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *StockVersion) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+// 필드 하나의 변경
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // 예: close, limitPrice.G1.sellPrice[0] (빈 값이면 값 전체)
+	Op            string                 `protobuf:"bytes,2,opt,name=op,proto3" json:"op,omitempty"`     // added, removed, changed
+	From          string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"` // 이전 값 (JSON)
+	To            string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`     // 새 값 (JSON)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FieldChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *FieldChange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FieldChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type StockDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	From          *StockVersion          `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *StockVersion          `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockDiff) Reset() {
+	*x = StockDiff{}
+	mi := &file_proto_get_stockmaster_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockDiff) ProtoMessage() {}
+
+func (x *StockDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_get_stockmaster_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockDiff.ProtoReflect.Descriptor instead.
+func (*StockDiff) Descriptor() ([]byte, []int) {
+	return file_proto_get_stockmaster_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StockDiff) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *StockDiff) GetFrom() *StockVersion {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *StockDiff) GetTo() *StockVersion {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *StockDiff) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+var File_proto_get_stockmaster_proto protoreflect.FileDescriptor
+
+const file_proto_get_stockmaster_proto_rawDesc = "" +
+	"\n" +
+	"\x1bproto/get_stockmaster.proto\x12\x05proto\x1a\x1cgoogle/api/annotations.proto\"<\n" +
+	"\fStockRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\badjusted\x18\x02 \x01(\bR\badjusted\"#\n" +
+	"\vStockMaster\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\":\n" +
+	"\x10OrderBookRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05board\x18\x02 \x01(\tR\x05board\"g\n" +
+	"\n" +
+	"PriceLevel\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x03R\x05price\x12\x16\n" +
+	"\x06volume\x18\x02 \x01(\x03R\x06volume\x12+\n" +
+	"\x11cumulative_volume\x18\x03 \x01(\x03R\x10cumulativeVolume\"\x9f\x03\n" +
+	"\tOrderBook\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05board\x18\x02 \x01(\tR\x05board\x12\x12\n" +
+	"\x04time\x18\x03 \x01(\tR\x04time\x12%\n" +
+	"\x04asks\x18\x04 \x03(\v2\x11.proto.PriceLevelR\x04asks\x12%\n" +
+	"\x04bids\x18\x05 \x03(\v2\x11.proto.PriceLevelR\x04bids\x12\x1e\n" +
+	"\bbest_ask\x18\x06 \x01(\x03H\x00R\abestAsk\x88\x01\x01\x12\x1e\n" +
+	"\bbest_bid\x18\a \x01(\x03H\x01R\abestBid\x88\x01\x01\x12\x1b\n" +
+	"\x06spread\x18\b \x01(\x03H\x02R\x06spread\x88\x01\x01\x12 \n" +
+	"\tmid_price\x18\t \x01(\x01H\x03R\bmidPrice\x88\x01\x01\x12\x1b\n" +
+	"\task_depth\x18\n" +
+	" \x01(\x03R\baskDepth\x12\x1b\n" +
+	"\tbid_depth\x18\v \x01(\x03R\bbidDepth\x12\x1c\n" +
+	"\timbalance\x18\f \x01(\x01R\timbalanceB\v\n" +
+	"\t_best_askB\v\n" +
+	"\t_best_bidB\t\n" +
+	"\a_spreadB\f\n" +
+	"\n" +
+	"_mid_price\"\x80\x01\n" +
+	"\x0eCandlesRequest\x12\x12\n" +
+	"\x04isin\x18\x01 \x01(\tR\x04isin\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1a\n" +
+	"\binterval\x18\x04 \x01(\tR\binterval\x12\x1a\n" +
+	"\badjusted\x18\x05 \x01(\bR\badjusted\"\x9c\x01\n" +
+	"\x06Candle\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\tR\x04time\x12\x12\n" +
+	"\x04open\x18\x02 \x01(\x03R\x04open\x12\x12\n" +
+	"\x04high\x18\x03 \x01(\x03R\x04high\x12\x10\n" +
+	"\x03low\x18\x04 \x01(\x03R\x03low\x12\x14\n" +
+	"\x05close\x18\x05 \x01(\x03R\x05close\x12\x16\n" +
+	"\x06volume\x18\x06 \x01(\x03R\x06volume\x12\x16\n" +
+	"\x06halted\x18\a \x01(\bR\x06halted\"b\n" +
+	"\aCandles\x12\x12\n" +
+	"\x04isin\x18\x01 \x01(\tR\x04isin\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\tR\binterval\x12'\n" +
+	"\acandles\x18\x03 \x03(\v2\r.proto.CandleR\acandles\"=\n" +
+	"\x13ListVIEventsRequest\x12\x12\n" +
+	"\x04isin\x18\x01 \x01(\tR\x04isin\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\"*\n" +
+	"\x14WatchVIEventsRequest\x12\x12\n" +
+	"\x04isin\x18\x01 \x01(\tR\x04isin\"\x82\x03\n" +
+	"\aVIEvent\x12\x12\n" +
+	"\x04isin\x18\x01 \x01(\tR\x04isin\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x12\n" +
+	"\x04time\x18\x04 \x01(\tR\x04time\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"apply_code\x18\x06 \x01(\tR\tapplyCode\x12#\n" +
+	"\rtrigger_count\x18\a \x01(\x03R\ftriggerCount\x12#\n" +
+	"\rtrigger_price\x18\b \x01(\x03R\ftriggerPrice\x12*\n" +
+	"\x11static_base_price\x18\t \x01(\x03R\x0fstaticBasePrice\x12,\n" +
+	"\x12dynamic_base_price\x18\n" +
+	" \x01(\x03R\x10dynamicBasePrice\x12&\n" +
+	"\x0fstatic_gap_rate\x18\v \x01(\x01R\rstaticGapRate\x12(\n" +
+	"\x10dynamic_gap_rate\x18\f \x01(\x01R\x0edynamicGapRate\"Z\n" +
+	"\bVIEvents\x12\x12\n" +
+	"\x04isin\x18\x01 \x01(\tR\x04isin\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12&\n" +
+	"\x06events\x18\x03 \x03(\v2\x0e.proto.VIEventR\x06events\"\xa5\x03\n" +
+	"\fStockSummary\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"short_code\x18\x03 \x01(\tR\tshortCode\x12\x16\n" +
+	"\x06market\x18\x04 \x01(\tR\x06market\x12$\n" +
+	"\x0estock_group_id\x18\x05 \x01(\tR\fstockGroupId\x12\x14\n" +
+	"\x05close\x18\x06 \x01(\x03R\x05close\x12\x1d\n" +
+	"\n" +
+	"prev_close\x18\a \x01(\x03R\tprevClose\x12\x16\n" +
+	"\x06change\x18\b \x01(\x03R\x06change\x12\x1f\n" +
+	"\vchange_rate\x18\t \x01(\x01R\n" +
+	"changeRate\x12\x16\n" +
+	"\x06volume\x18\n" +
+	" \x01(\x03R\x06volume\x12\x16\n" +
+	"\x06amount\x18\v \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06halted\x18\f \x01(\bR\x06halted\x12\x1b\n" +
+	"\tvi_active\x18\r \x01(\bR\bviActive\x12\x17\n" +
+	"\avi_kind\x18\x0e \x01(\tR\x06viKind\x12&\n" +
+	"\x0fvi_trigger_time\x18\x0f \x01(\tR\rviTriggerTime\"\x9f\x01\n" +
+	"\x0fRankingsRequest\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x0e\n" +
+	"\x02by\x18\x02 \x01(\tR\x02by\x12\x14\n" +
+	"\x05order\x18\x03 \x01(\tR\x05order\x12\x16\n" +
+	"\x06market\x18\x04 \x01(\tR\x06market\x12$\n" +
+	"\x0estock_group_id\x18\x05 \x01(\tR\fstockGroupId\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"\x87\x01\n" +
+	"\bRankings\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x0e\n" +
+	"\x02by\x18\x02 \x01(\tR\x02by\x12\x14\n" +
+	"\x05order\x18\x03 \x01(\tR\x05order\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12+\n" +
+	"\x06stocks\x18\x05 \x03(\v2\x13.proto.StockSummaryR\x06stocks\"\x7f\n" +
+	"\x15MarketOverviewRequest\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x16\n" +
+	"\x06market\x18\x02 \x01(\tR\x06market\x12$\n" +
+	"\x0estock_group_id\x18\x03 \x01(\tR\fstockGroupId\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xd0\x02\n" +
+	"\x0eMarketOverview\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1c\n" +
+	"\tadvancing\x18\x03 \x01(\x05R\tadvancing\x12\x1c\n" +
+	"\tdeclining\x18\x04 \x01(\x05R\tdeclining\x12\x1c\n" +
+	"\tunchanged\x18\x05 \x01(\x05R\tunchanged\x12-\n" +
+	"\againers\x18\x06 \x03(\v2\x13.proto.StockSummaryR\againers\x12+\n" +
+	"\x06losers\x18\a \x03(\v2\x13.proto.StockSummaryR\x06losers\x124\n" +
+	"\vmost_traded\x18\b \x03(\v2\x13.proto.StockSummaryR\n" +
+	"mostTraded\x12(\n" +
+	"\x05in_vi\x18\t \x03(\v2\x13.proto.StockSummaryR\x04inVi\"C\n" +
+	"\vDiffRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"V\n" +
+	"\fStockVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x04R\aversion\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\tR\x04time\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\bR\adeleted\"U\n" +
+	"\vFieldChange\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x0e\n" +
+	"\x02op\x18\x02 \x01(\tR\x02op\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"\x99\x01\n" +
+	"\tStockDiff\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x04from\x18\x02 \x01(\v2\x13.proto.StockVersionR\x04from\x12#\n" +
+	"\x02to\x18\x03 \x01(\v2\x13.proto.StockVersionR\x02to\x12,\n" +
+	"\achanges\x18\x04 \x03(\v2\x12.proto.FieldChangeR\achanges2\xc4\x05\n" +
+	"\fStockService\x12S\n" +
+	"\x0eGetStockMaster\x12\x13.proto.StockRequest\x1a\x12.proto.StockMaster\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/stocks/{key}\x12]\n" +
+	"\fGetOrderBook\x12\x17.proto.OrderBookRequest\x1a\x10.proto.OrderBook\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/stocks/{key}/orderbook\x12O\n" +
+	"\n" +
+	"GetCandles\x12\x15.proto.CandlesRequest\x1a\x0e.proto.Candles\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/candles/{isin}\x12Y\n" +
+	"\fListVIEvents\x12\x1a.proto.ListVIEventsRequest\x1a\x0f.proto.VIEvents\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/vi/{isin}/events\x12U\n" +
+	"\rWatchVIEvents\x12\x1b.proto.WatchVIEventsRequest\x1a\x0e.proto.VIEvent\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/vi/stream0\x01\x12S\n" +
+	"\vGetRankings\x12\x16.proto.RankingsRequest\x1a\x0f.proto.Rankings\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/market/rankings\x12e\n" +
+	"\x11GetMarketOverview\x12\x1c.proto.MarketOverviewRequest\x1a\x15.proto.MarketOverview\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/market/overview\x12A\n" +
+	"\aGetDiff\x12\x12.proto.DiffRequest\x1a\x10.proto.StockDiff\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/v1/diffB\x11Z\x0fproto/generatedb\x06proto3"
 
 var (
 	file_proto_get_stockmaster_proto_rawDescOnce sync.Once
@@ -133,19 +1803,64 @@ func file_proto_get_stockmaster_proto_rawDescGZIP() []byte {
 	return file_proto_get_stockmaster_proto_rawDescData
 }
 
-var file_proto_get_stockmaster_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_get_stockmaster_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_proto_get_stockmaster_proto_goTypes = []any{
-	(*StockRequest)(nil), // 0: proto.StockRequest
-	(*StockMaster)(nil),  // 1: proto.StockMaster
+	(*StockRequest)(nil),          // 0: proto.StockRequest
+	(*StockMaster)(nil),           // 1: proto.StockMaster
+	(*OrderBookRequest)(nil),      // 2: proto.OrderBookRequest
+	(*PriceLevel)(nil),            // 3: proto.PriceLevel
+	(*OrderBook)(nil),             // 4: proto.OrderBook
+	(*CandlesRequest)(nil),        // 5: proto.CandlesRequest
+	(*Candle)(nil),                // 6: proto.Candle
+	(*Candles)(nil),               // 7: proto.Candles
+	(*ListVIEventsRequest)(nil),   // 8: proto.ListVIEventsRequest
+	(*WatchVIEventsRequest)(nil),  // 9: proto.WatchVIEventsRequest
+	(*VIEvent)(nil),               // 10: proto.VIEvent
+	(*VIEvents)(nil),              // 11: proto.VIEvents
+	(*StockSummary)(nil),          // 12: proto.StockSummary
+	(*RankingsRequest)(nil),       // 13: proto.RankingsRequest
+	(*Rankings)(nil),              // 14: proto.Rankings
+	(*MarketOverviewRequest)(nil), // 15: proto.MarketOverviewRequest
+	(*MarketOverview)(nil),        // 16: proto.MarketOverview
+	(*DiffRequest)(nil),           // 17: proto.DiffRequest
+	(*StockVersion)(nil),          // 18: proto.StockVersion
+	(*FieldChange)(nil),           // 19: proto.FieldChange
+	(*StockDiff)(nil),             // 20: proto.StockDiff
 }
 var file_proto_get_stockmaster_proto_depIdxs = []int32{
-	0, // 0: proto.StockService.GetStockMaster:input_type -> proto.StockRequest
-	1, // 1: proto.StockService.GetStockMaster:output_type -> proto.StockMaster
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3,  // 0: proto.OrderBook.asks:type_name -> proto.PriceLevel
+	3,  // 1: proto.OrderBook.bids:type_name -> proto.PriceLevel
+	6,  // 2: proto.Candles.candles:type_name -> proto.Candle
+	10, // 3: proto.VIEvents.events:type_name -> proto.VIEvent
+	12, // 4: proto.Rankings.stocks:type_name -> proto.StockSummary
+	12, // 5: proto.MarketOverview.gainers:type_name -> proto.StockSummary
+	12, // 6: proto.MarketOverview.losers:type_name -> proto.StockSummary
+	12, // 7: proto.MarketOverview.most_traded:type_name -> proto.StockSummary
+	12, // 8: proto.MarketOverview.in_vi:type_name -> proto.StockSummary
+	18, // 9: proto.StockDiff.from:type_name -> proto.StockVersion
+	18, // 10: proto.StockDiff.to:type_name -> proto.StockVersion
+	19, // 11: proto.StockDiff.changes:type_name -> proto.FieldChange
+	0,  // 12: proto.StockService.GetStockMaster:input_type -> proto.StockRequest
+	2,  // 13: proto.StockService.GetOrderBook:input_type -> proto.OrderBookRequest
+	5,  // 14: proto.StockService.GetCandles:input_type -> proto.CandlesRequest
+	8,  // 15: proto.StockService.ListVIEvents:input_type -> proto.ListVIEventsRequest
+	9,  // 16: proto.StockService.WatchVIEvents:input_type -> proto.WatchVIEventsRequest
+	13, // 17: proto.StockService.GetRankings:input_type -> proto.RankingsRequest
+	15, // 18: proto.StockService.GetMarketOverview:input_type -> proto.MarketOverviewRequest
+	17, // 19: proto.StockService.GetDiff:input_type -> proto.DiffRequest
+	1,  // 20: proto.StockService.GetStockMaster:output_type -> proto.StockMaster
+	4,  // 21: proto.StockService.GetOrderBook:output_type -> proto.OrderBook
+	7,  // 22: proto.StockService.GetCandles:output_type -> proto.Candles
+	11, // 23: proto.StockService.ListVIEvents:output_type -> proto.VIEvents
+	10, // 24: proto.StockService.WatchVIEvents:output_type -> proto.VIEvent
+	14, // 25: proto.StockService.GetRankings:output_type -> proto.Rankings
+	16, // 26: proto.StockService.GetMarketOverview:output_type -> proto.MarketOverview
+	20, // 27: proto.StockService.GetDiff:output_type -> proto.StockDiff
+	20, // [20:28] is the sub-list for method output_type
+	12, // [12:20] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_proto_get_stockmaster_proto_init() }
@@ -153,13 +1868,14 @@ func file_proto_get_stockmaster_proto_init() {
 	if File_proto_get_stockmaster_proto != nil {
 		return
 	}
+	file_proto_get_stockmaster_proto_msgTypes[4].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_get_stockmaster_proto_rawDesc), len(file_proto_get_stockmaster_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,14 +19,36 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StockService_GetStockMaster_FullMethodName = "/proto.StockService/GetStockMaster"
+	StockService_GetStockMaster_FullMethodName    = "/proto.StockService/GetStockMaster"
+	StockService_GetOrderBook_FullMethodName      = "/proto.StockService/GetOrderBook"
+	StockService_GetCandles_FullMethodName        = "/proto.StockService/GetCandles"
+	StockService_ListVIEvents_FullMethodName      = "/proto.StockService/ListVIEvents"
+	StockService_WatchVIEvents_FullMethodName     = "/proto.StockService/WatchVIEvents"
+	StockService_GetRankings_FullMethodName       = "/proto.StockService/GetRankings"
+	StockService_GetMarketOverview_FullMethodName = "/proto.StockService/GetMarketOverview"
+	StockService_GetDiff_FullMethodName           = "/proto.StockService/GetDiff"
 )
 
 // StockServiceClient is the client API for StockService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StockServiceClient interface {
+	// REST: GET /v1/stocks/{key} (grpc-gateway)
 	GetStockMaster(ctx context.Context, in *StockRequest, opts ...grpc.CallOption) (*StockMaster, error)
+	// REST: GET /v1/stocks/{key}/orderbook?board=G1 (grpc-gateway)
+	GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBook, error)
+	// REST: GET /v1/candles/{isin}?from=20250429&to=20250429&interval=5m (grpc-gateway)
+	GetCandles(ctx context.Context, in *CandlesRequest, opts ...grpc.CallOption) (*Candles, error)
+	// REST: GET /v1/vi/{isin}/events?date=20250429 (grpc-gateway)
+	ListVIEvents(ctx context.Context, in *ListVIEventsRequest, opts ...grpc.CallOption) (*VIEvents, error)
+	// 새로 저장되는 VI 이벤트 (REST: GET /v1/vi/stream?isin=..., 줄 단위 JSON)
+	WatchVIEvents(ctx context.Context, in *WatchVIEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VIEvent], error)
+	// REST: GET /v1/market/rankings?date=20250429&by=amount&market=S (grpc-gateway)
+	GetRankings(ctx context.Context, in *RankingsRequest, opts ...grpc.CallOption) (*Rankings, error)
+	// REST: GET /v1/market/overview?date=20250429 (grpc-gateway)
+	GetMarketOverview(ctx context.Context, in *MarketOverviewRequest, opts ...grpc.CallOption) (*MarketOverview, error)
+	// REST: GET /v1/diff?key=stock:20250429:KR7005930003&from=...&to=... (grpc-gateway)
+	GetDiff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*StockDiff, error)
 }
 
 type stockServiceClient struct {
@@ -47,11 +69,105 @@ func (c *stockServiceClient) GetStockMaster(ctx context.Context, in *StockReques
 	return out, nil
 }
 
+func (c *stockServiceClient) GetOrderBook(ctx context.Context, in *OrderBookRequest, opts ...grpc.CallOption) (*OrderBook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderBook)
+	err := c.cc.Invoke(ctx, StockService_GetOrderBook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) GetCandles(ctx context.Context, in *CandlesRequest, opts ...grpc.CallOption) (*Candles, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Candles)
+	err := c.cc.Invoke(ctx, StockService_GetCandles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ListVIEvents(ctx context.Context, in *ListVIEventsRequest, opts ...grpc.CallOption) (*VIEvents, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VIEvents)
+	err := c.cc.Invoke(ctx, StockService_ListVIEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) WatchVIEvents(ctx context.Context, in *WatchVIEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[VIEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StockService_ServiceDesc.Streams[0], StockService_WatchVIEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchVIEventsRequest, VIEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_WatchVIEventsClient = grpc.ServerStreamingClient[VIEvent]
+
+func (c *stockServiceClient) GetRankings(ctx context.Context, in *RankingsRequest, opts ...grpc.CallOption) (*Rankings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rankings)
+	err := c.cc.Invoke(ctx, StockService_GetRankings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) GetMarketOverview(ctx context.Context, in *MarketOverviewRequest, opts ...grpc.CallOption) (*MarketOverview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarketOverview)
+	err := c.cc.Invoke(ctx, StockService_GetMarketOverview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) GetDiff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*StockDiff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockDiff)
+	err := c.cc.Invoke(ctx, StockService_GetDiff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StockServiceServer is the server API for StockService service.
 // All implementations must embed UnimplementedStockServiceServer
 // for forward compatibility.
 type StockServiceServer interface {
+	// REST: GET /v1/stocks/{key} (grpc-gateway)
 	GetStockMaster(context.Context, *StockRequest) (*StockMaster, error)
+	// REST: GET /v1/stocks/{key}/orderbook?board=G1 (grpc-gateway)
+	GetOrderBook(context.Context, *OrderBookRequest) (*OrderBook, error)
+	// REST: GET /v1/candles/{isin}?from=20250429&to=20250429&interval=5m (grpc-gateway)
+	GetCandles(context.Context, *CandlesRequest) (*Candles, error)
+	// REST: GET /v1/vi/{isin}/events?date=20250429 (grpc-gateway)
+	ListVIEvents(context.Context, *ListVIEventsRequest) (*VIEvents, error)
+	// 새로 저장되는 VI 이벤트 (REST: GET /v1/vi/stream?isin=..., 줄 단위 JSON)
+	WatchVIEvents(*WatchVIEventsRequest, grpc.ServerStreamingServer[VIEvent]) error
+	// REST: GET /v1/market/rankings?date=20250429&by=amount&market=S (grpc-gateway)
+	GetRankings(context.Context, *RankingsRequest) (*Rankings, error)
+	// REST: GET /v1/market/overview?date=20250429 (grpc-gateway)
+	GetMarketOverview(context.Context, *MarketOverviewRequest) (*MarketOverview, error)
+	// REST: GET /v1/diff?key=stock:20250429:KR7005930003&from=...&to=... (grpc-gateway)
+	GetDiff(context.Context, *DiffRequest) (*StockDiff, error)
 	mustEmbedUnimplementedStockServiceServer()
 }
 
@@ -65,6 +181,27 @@ type UnimplementedStockServiceServer struct{}
 func (UnimplementedStockServiceServer) GetStockMaster(context.Context, *StockRequest) (*StockMaster, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStockMaster not implemented")
 }
+func (UnimplementedStockServiceServer) GetOrderBook(context.Context, *OrderBookRequest) (*OrderBook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderBook not implemented")
+}
+func (UnimplementedStockServiceServer) GetCandles(context.Context, *CandlesRequest) (*Candles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandles not implemented")
+}
+func (UnimplementedStockServiceServer) ListVIEvents(context.Context, *ListVIEventsRequest) (*VIEvents, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVIEvents not implemented")
+}
+func (UnimplementedStockServiceServer) WatchVIEvents(*WatchVIEventsRequest, grpc.ServerStreamingServer[VIEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchVIEvents not implemented")
+}
+func (UnimplementedStockServiceServer) GetRankings(context.Context, *RankingsRequest) (*Rankings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRankings not implemented")
+}
+func (UnimplementedStockServiceServer) GetMarketOverview(context.Context, *MarketOverviewRequest) (*MarketOverview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarketOverview not implemented")
+}
+func (UnimplementedStockServiceServer) GetDiff(context.Context, *DiffRequest) (*StockDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiff not implemented")
+}
 func (UnimplementedStockServiceServer) mustEmbedUnimplementedStockServiceServer() {}
 func (UnimplementedStockServiceServer) testEmbeddedByValue()                      {}
 
@@ -104,6 +241,125 @@ func _StockService_GetStockMaster_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StockService_GetOrderBook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrderBookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetOrderBook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetOrderBook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetOrderBook(ctx, req.(*OrderBookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_GetCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetCandles(ctx, req.(*CandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ListVIEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVIEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ListVIEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ListVIEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ListVIEvents(ctx, req.(*ListVIEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_WatchVIEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVIEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StockServiceServer).WatchVIEvents(m, &grpc.GenericServerStream[WatchVIEventsRequest, VIEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StockService_WatchVIEventsServer = grpc.ServerStreamingServer[VIEvent]
+
+func _StockService_GetRankings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetRankings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetRankings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetRankings(ctx, req.(*RankingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_GetMarketOverview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarketOverviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetMarketOverview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetMarketOverview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetMarketOverview(ctx, req.(*MarketOverviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_GetDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetDiff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetDiff(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StockService_ServiceDesc is the grpc.ServiceDesc for StockService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +371,37 @@ var StockService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStockMaster",
 			Handler:    _StockService_GetStockMaster_Handler,
 		},
+		{
+			MethodName: "GetOrderBook",
+			Handler:    _StockService_GetOrderBook_Handler,
+		},
+		{
+			MethodName: "GetCandles",
+			Handler:    _StockService_GetCandles_Handler,
+		},
+		{
+			MethodName: "ListVIEvents",
+			Handler:    _StockService_ListVIEvents_Handler,
+		},
+		{
+			MethodName: "GetRankings",
+			Handler:    _StockService_GetRankings_Handler,
+		},
+		{
+			MethodName: "GetMarketOverview",
+			Handler:    _StockService_GetMarketOverview_Handler,
+		},
+		{
+			MethodName: "GetDiff",
+			Handler:    _StockService_GetDiff_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchVIEvents",
+			Handler:       _StockService_WatchVIEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/get_stockmaster.proto",
 }
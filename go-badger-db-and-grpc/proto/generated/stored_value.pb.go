@@ -0,0 +1,370 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: proto/stored_value.proto
+
+package generated
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 저장 형식 proto, proto+zstd 로 저장하는 JSON 값
+// google.protobuf.Struct 와 달리 객체의 키 순서와 숫자 표기를 그대로 보존합니다
+type JsonValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*JsonValue_NullValue
+	//	*JsonValue_BoolValue
+	//	*JsonValue_IntValue
+	//	*JsonValue_NumberValue
+	//	*JsonValue_StringValue
+	//	*JsonValue_ObjectValue
+	//	*JsonValue_ArrayValue
+	Kind          isJsonValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JsonValue) Reset() {
+	*x = JsonValue{}
+	mi := &file_proto_stored_value_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JsonValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonValue) ProtoMessage() {}
+
+func (x *JsonValue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stored_value_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonValue.ProtoReflect.Descriptor instead.
+func (*JsonValue) Descriptor() ([]byte, []int) {
+	return file_proto_stored_value_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *JsonValue) GetKind() isJsonValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *JsonValue) GetNullValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*JsonValue_NullValue); ok {
+			return x.NullValue
+		}
+	}
+	return false
+}
+
+func (x *JsonValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*JsonValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *JsonValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*JsonValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *JsonValue) GetNumberValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*JsonValue_NumberValue); ok {
+			return x.NumberValue
+		}
+	}
+	return ""
+}
+
+func (x *JsonValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*JsonValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *JsonValue) GetObjectValue() *JsonObject {
+	if x != nil {
+		if x, ok := x.Kind.(*JsonValue_ObjectValue); ok {
+			return x.ObjectValue
+		}
+	}
+	return nil
+}
+
+func (x *JsonValue) GetArrayValue() *JsonArray {
+	if x != nil {
+		if x, ok := x.Kind.(*JsonValue_ArrayValue); ok {
+			return x.ArrayValue
+		}
+	}
+	return nil
+}
+
+type isJsonValue_Kind interface {
+	isJsonValue_Kind()
+}
+
+type JsonValue_NullValue struct {
+	NullValue bool `protobuf:"varint,1,opt,name=null_value,json=nullValue,proto3,oneof"`
+}
+
+type JsonValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type JsonValue_IntValue struct {
+	IntValue int64 `protobuf:"zigzag64,3,opt,name=int_value,json=intValue,proto3,oneof"` // 정수 (int64 범위이고 표기가 그대로 돌아오는 값)
+}
+
+type JsonValue_NumberValue struct {
+	NumberValue string `protobuf:"bytes,4,opt,name=number_value,json=numberValue,proto3,oneof"` // 그 밖의 숫자 (원래 표기)
+}
+
+type JsonValue_StringValue struct {
+	StringValue string `protobuf:"bytes,5,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type JsonValue_ObjectValue struct {
+	ObjectValue *JsonObject `protobuf:"bytes,6,opt,name=object_value,json=objectValue,proto3,oneof"`
+}
+
+type JsonValue_ArrayValue struct {
+	ArrayValue *JsonArray `protobuf:"bytes,7,opt,name=array_value,json=arrayValue,proto3,oneof"`
+}
+
+func (*JsonValue_NullValue) isJsonValue_Kind() {}
+
+func (*JsonValue_BoolValue) isJsonValue_Kind() {}
+
+func (*JsonValue_IntValue) isJsonValue_Kind() {}
+
+func (*JsonValue_NumberValue) isJsonValue_Kind() {}
+
+func (*JsonValue_StringValue) isJsonValue_Kind() {}
+
+func (*JsonValue_ObjectValue) isJsonValue_Kind() {}
+
+func (*JsonValue_ArrayValue) isJsonValue_Kind() {}
+
+// 키 순서대로 keys[i] 의 값이 values[i] 입니다
+type JsonObject struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []string               `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	Values        []*JsonValue           `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JsonObject) Reset() {
+	*x = JsonObject{}
+	mi := &file_proto_stored_value_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JsonObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonObject) ProtoMessage() {}
+
+func (x *JsonObject) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stored_value_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonObject.ProtoReflect.Descriptor instead.
+func (*JsonObject) Descriptor() ([]byte, []int) {
+	return file_proto_stored_value_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *JsonObject) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *JsonObject) GetValues() []*JsonValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type JsonArray struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*JsonValue           `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JsonArray) Reset() {
+	*x = JsonArray{}
+	mi := &file_proto_stored_value_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JsonArray) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JsonArray) ProtoMessage() {}
+
+func (x *JsonArray) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_stored_value_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JsonArray.ProtoReflect.Descriptor instead.
+func (*JsonArray) Descriptor() ([]byte, []int) {
+	return file_proto_stored_value_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JsonArray) GetValues() []*JsonValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_proto_stored_value_proto protoreflect.FileDescriptor
+
+const file_proto_stored_value_proto_rawDesc = "" +
+	"\n" +
+	"\x18proto/stored_value.proto\x12\x05proto\"\xab\x02\n" +
+	"\tJsonValue\x12\x1f\n" +
+	"\n" +
+	"null_value\x18\x01 \x01(\bH\x00R\tnullValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x02 \x01(\bH\x00R\tboolValue\x12\x1d\n" +
+	"\tint_value\x18\x03 \x01(\x12H\x00R\bintValue\x12#\n" +
+	"\fnumber_value\x18\x04 \x01(\tH\x00R\vnumberValue\x12#\n" +
+	"\fstring_value\x18\x05 \x01(\tH\x00R\vstringValue\x126\n" +
+	"\fobject_value\x18\x06 \x01(\v2\x11.proto.JsonObjectH\x00R\vobjectValue\x123\n" +
+	"\varray_value\x18\a \x01(\v2\x10.proto.JsonArrayH\x00R\n" +
+	"arrayValueB\x06\n" +
+	"\x04kind\"J\n" +
+	"\n" +
+	"JsonObject\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\x12(\n" +
+	"\x06values\x18\x02 \x03(\v2\x10.proto.JsonValueR\x06values\"5\n" +
+	"\tJsonArray\x12(\n" +
+	"\x06values\x18\x01 \x03(\v2\x10.proto.JsonValueR\x06valuesB\x11Z\x0fproto/generatedb\x06proto3"
+
+var (
+	file_proto_stored_value_proto_rawDescOnce sync.Once
+	file_proto_stored_value_proto_rawDescData []byte
+)
+
+func file_proto_stored_value_proto_rawDescGZIP() []byte {
+	file_proto_stored_value_proto_rawDescOnce.Do(func() {
+		file_proto_stored_value_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_stored_value_proto_rawDesc), len(file_proto_stored_value_proto_rawDesc)))
+	})
+	return file_proto_stored_value_proto_rawDescData
+}
+
+var file_proto_stored_value_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_stored_value_proto_goTypes = []any{
+	(*JsonValue)(nil),  // 0: proto.JsonValue
+	(*JsonObject)(nil), // 1: proto.JsonObject
+	(*JsonArray)(nil),  // 2: proto.JsonArray
+}
+var file_proto_stored_value_proto_depIdxs = []int32{
+	1, // 0: proto.JsonValue.object_value:type_name -> proto.JsonObject
+	2, // 1: proto.JsonValue.array_value:type_name -> proto.JsonArray
+	0, // 2: proto.JsonObject.values:type_name -> proto.JsonValue
+	0, // 3: proto.JsonArray.values:type_name -> proto.JsonValue
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_stored_value_proto_init() }
+func file_proto_stored_value_proto_init() {
+	if File_proto_stored_value_proto != nil {
+		return
+	}
+	file_proto_stored_value_proto_msgTypes[0].OneofWrappers = []any{
+		(*JsonValue_NullValue)(nil),
+		(*JsonValue_BoolValue)(nil),
+		(*JsonValue_IntValue)(nil),
+		(*JsonValue_NumberValue)(nil),
+		(*JsonValue_StringValue)(nil),
+		(*JsonValue_ObjectValue)(nil),
+		(*JsonValue_ArrayValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_stored_value_proto_rawDesc), len(file_proto_stored_value_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_stored_value_proto_goTypes,
+		DependencyIndexes: file_proto_stored_value_proto_depIdxs,
+		MessageInfos:      file_proto_stored_value_proto_msgTypes,
+	}.Build()
+	File_proto_stored_value_proto = out.File
+	file_proto_stored_value_proto_goTypes = nil
+	file_proto_stored_value_proto_depIdxs = nil
+}
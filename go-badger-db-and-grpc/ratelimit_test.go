@@ -0,0 +1,66 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/yiminan/go-examples/go-ratelimit"
+)
+
+func TestSetGetRateLimit(t *testing.T) {
+	var err error
+	db, err = badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	initData()
+
+	l := ratelimit.New(ratelimit.Limit{Rate: 1, Burst: 2}, map[string]ratelimit.Limit{"/set": {Rate: 0.5, Burst: 1}}, nil, 0, nil, rejectRateLimited)
+	now := time.Unix(1_700_000_000, 0)
+	l.Now = func() time.Time { return now }
+	get := l.Wrap("/get", getHandler)
+	set := l.Wrap("/set", setHandler)
+
+	do := func(h http.HandlerFunc, req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec
+	}
+	getReq := func() *http.Request {
+		return httptest.NewRequest(http.MethodGet, "/get?key=stock:20250428:KR7005930003", nil)
+	}
+	setReq := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/set", strings.NewReader(`{"key":"k","value":"v"}`))
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := do(get, getReq()); rec.Code != http.StatusOK {
+			t.Fatalf("/get %d번째: status %d", i+1, rec.Code)
+		}
+	}
+	rec := do(get, getReq())
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Fatalf("/get 초과: status %d, Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// /set 은 라우트 한도(2초에 1번)
+	if rec := do(set, setReq()); rec.Code != http.StatusOK {
+		t.Fatalf("/set: status %d", rec.Code)
+	}
+	rec = do(set, setReq())
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "2" {
+		t.Fatalf("/set 초과: status %d, Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// 시간이 지나면 다시 허용
+	now = now.Add(2 * time.Second)
+	if rec := do(set, setReq()); rec.Code != http.StatusOK {
+		t.Errorf("2초 후 /set: status %d", rec.Code)
+	}
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/dgraph-io/badger/v4"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+	"github.com/yiminan/go-examples/go-ratelimit"
+)
+
+// 저장 값의 첫 바이트(형식 헤더)
+// JSON 은 제어 문자로 시작할 수 없으므로, 첫 바이트가 아래 값이 아니면 헤더 없이 저장된 기존 JSON 으로 읽습니다
+const (
+	formatRaw       byte = 0x00 // 헤더 + 원본 (압축해도 줄지 않는 작은 값)
+	formatZstd      byte = 0x01 // zstd 압축
+	formatSnappy    byte = 0x02 // snappy 압축
+	formatProto     byte = 0x03 // protobuf JsonValue (JSON 객체만, 키 순서와 숫자 표기 보존)
+	formatProtoZstd byte = 0x04 // protobuf JsonValue + zstd
+	formatLegacy    byte = 0xff // 헤더 없는 기존 값 (통계용, 저장하지 않음)
+)
+
+// 저장 형식 이름 (-value-encoding)
+var valueEncodings = map[string]byte{
+	"raw":        formatRaw,
+	"zstd":       formatZstd,
+	"snappy":     formatSnappy,
+	"proto":      formatProto,
+	"proto+zstd": formatProtoZstd,
+}
+
+func formatName(f byte) string {
+	if f == formatLegacy {
+		return "legacy"
+	}
+	for name, v := range valueEncodings {
+		if v == f {
+			return name
+		}
+	}
+	return fmt.Sprintf("0x%02x", f)
+}
+
+// 새로 저장하는 값의 형식
+var valueFormat = formatZstd
+
+// EncodeAll/DecodeAll 은 여러 goroutine 에서 함께 사용할 수 있음
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// 값을 저장 형식으로 변환합니다
+// protobuf 형식은 JSON 객체에만 적용하고, 그 밖의 값이나 변환해도 줄지 않는 값은 헤더 + 원본으로 저장합니다
+func encodeValue(format byte, val []byte) []byte {
+	var payload []byte
+	switch format {
+	case formatZstd:
+		payload = zstdEncoder.EncodeAll(val, nil)
+	case formatSnappy:
+		payload = s2.EncodeSnappy(nil, val)
+	case formatProto, formatProtoZstd:
+		v, err := jsonToProto(val)
+		if err != nil || v.GetObjectValue() == nil {
+			return encodeValue(formatRaw, val)
+		}
+		if payload, err = proto.Marshal(v); err != nil {
+			return encodeValue(formatRaw, val)
+		}
+		if format == formatProtoZstd {
+			payload = zstdEncoder.EncodeAll(payload, nil)
+		}
+	}
+	if format == formatRaw || len(payload) >= len(val) {
+		format, payload = formatRaw, val
+	}
+	return append([]byte{format}, payload...)
+}
+
+// 저장된 값의 형식을 반환합니다
+func storedFormat(stored []byte) byte {
+	if len(stored) > 0 && stored[0] <= formatProtoZstd {
+		return stored[0]
+	}
+	return formatLegacy
+}
+
+// 저장된 값을 원래 값으로 되돌립니다 (기존 JSON 과 새 형식을 모두 읽음)
+// protobuf 형식은 공백 없는 JSON 으로 돌아옵니다 (키 순서와 숫자 표기는 그대로)
+func decodeValue(stored []byte) ([]byte, error) {
+	format := storedFormat(stored)
+	if format == formatLegacy {
+		return stored, nil
+	}
+	payload := stored[1:]
+	switch format {
+	case formatZstd:
+		return zstdDecoder.DecodeAll(payload, nil)
+	case formatSnappy:
+		return s2.Decode(nil, payload)
+	case formatProto, formatProtoZstd:
+		if format == formatProtoZstd {
+			var err error
+			if payload, err = zstdDecoder.DecodeAll(payload, nil); err != nil {
+				return nil, err
+			}
+		}
+		var v pb.JsonValue
+		if err := proto.Unmarshal(payload, &v); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := writeJSON(&buf, &v); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return payload, nil
+	}
+}
+
+// JSON 을 JsonValue 로 변환합니다 (값 하나만 있어야 함)
+func jsonToProto(val []byte) (*pb.JsonValue, error) {
+	dec := json.NewDecoder(bytes.NewReader(val))
+	dec.UseNumber()
+	v, err := readJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("trailing data after JSON value")
+	}
+	return v, nil
+}
+
+func readJSONValue(dec *json.Decoder) (*pb.JsonValue, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case nil:
+		return &pb.JsonValue{Kind: &pb.JsonValue_NullValue{NullValue: true}}, nil
+	case bool:
+		return &pb.JsonValue{Kind: &pb.JsonValue_BoolValue{BoolValue: t}}, nil
+	case json.Number:
+		// 표기가 그대로 돌아오는 정수만 varint 로, 그 밖의 숫자(1.50, 1e3 등)는 원래 표기로 저장
+		if i, err := strconv.ParseInt(t.String(), 10, 64); err == nil && strconv.FormatInt(i, 10) == t.String() {
+			return &pb.JsonValue{Kind: &pb.JsonValue_IntValue{IntValue: i}}, nil
+		}
+		return &pb.JsonValue{Kind: &pb.JsonValue_NumberValue{NumberValue: t.String()}}, nil
+	case string:
+		return &pb.JsonValue{Kind: &pb.JsonValue_StringValue{StringValue: t}}, nil
+	case json.Delim:
+		if t == '{' {
+			obj := &pb.JsonObject{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				v, err := readJSONValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj.Keys = append(obj.Keys, key.(string))
+				obj.Values = append(obj.Values, v)
+			}
+			_, err := dec.Token() // }
+			return &pb.JsonValue{Kind: &pb.JsonValue_ObjectValue{ObjectValue: obj}}, err
+		}
+		arr := &pb.JsonArray{}
+		for dec.More() {
+			v, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr.Values = append(arr.Values, v)
+		}
+		_, err := dec.Token() // ]
+		return &pb.JsonValue{Kind: &pb.JsonValue_ArrayValue{ArrayValue: arr}}, err
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
+
+// JsonValue 를 공백 없는 JSON 으로 씁니다
+func writeJSON(buf *bytes.Buffer, v *pb.JsonValue) error {
+	switch k := v.GetKind().(type) {
+	case *pb.JsonValue_NullValue:
+		buf.WriteString("null")
+	case *pb.JsonValue_BoolValue:
+		buf.WriteString(strconv.FormatBool(k.BoolValue))
+	case *pb.JsonValue_IntValue:
+		buf.WriteString(strconv.FormatInt(k.IntValue, 10))
+	case *pb.JsonValue_NumberValue:
+		buf.WriteString(k.NumberValue)
+	case *pb.JsonValue_StringValue:
+		writeJSONString(buf, k.StringValue)
+	case *pb.JsonValue_ObjectValue:
+		obj := k.ObjectValue
+		if len(obj.Keys) != len(obj.Values) {
+			return errors.New("JSON object keys and values do not match")
+		}
+		buf.WriteByte('{')
+		for i, key := range obj.Keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, obj.Values[i]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case *pb.JsonValue_ArrayValue:
+		buf.WriteByte('[')
+		for i, e := range k.ArrayValue.Values {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		return errors.New("empty JSON value")
+	}
+	return nil
+}
+
+// 문자열을 JSON 으로 씁니다 (<, >, & 는 이스케이프하지 않음)
+func writeJSONString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	buf.Truncate(buf.Len() - 1) // Encode 가 붙인 줄바꿈
+}
+
+// 데이터 키인지 확인합니다 (레이트 리밋 카운터, 캐시 확인용 키, 저장 시각 키 제외)
+func isDataKey(key []byte) bool {
+	return !bytes.HasPrefix(key, []byte(ratelimit.KeyPrefix)) && !bytes.Equal(key, []byte(cacheProbeKey)) &&
+		!bytes.HasPrefix(key, []byte(versionTimeKeyPrefix))
+}
+
+// 값을 저장 형식으로 변환해 저장합니다
+// 종목 스냅샷이면 가격을 검증하고(-validation), 체결 시계열과 VI 이벤트도 함께 저장합니다
+// 저장 시각도 기록해 /diff 에서 버전을 시각으로 찾을 수 있게 합니다
+func putValue(txn *badger.Txn, key, val []byte) error {
+	meta, err := checkSnapshot(txn, key, val)
+	if err != nil {
+		return err
+	}
+	if err := recordTick(txn, key, val); err != nil {
+		return err
+	}
+	if err := recordVIEvents(txn, key, val); err != nil {
+		return err
+	}
+	if err := recordVersionTime(txn, key); err != nil {
+		return err
+	}
+	return txn.SetEntry(newEntry(key, encodeValue(valueFormat, val)).WithMeta(meta))
+}
+
+// FormatStats 는 저장 형식 하나의 통계입니다
+type FormatStats struct {
+	Keys        int   `json:"keys"`
+	StoredBytes int64 `json:"storedBytes"`
+	RawBytes    int64 `json:"rawBytes"` // 원래 값 크기
+}
+
+// StorageStats 는 저장 공간 사용량과 절감률입니다 (/storage/stats)
+type StorageStats struct {
+	Encoding    string                  `json:"encoding"` // 새로 저장하는 형식
+	Keys        int                     `json:"keys"`
+	StoredBytes int64                   `json:"storedBytes"`
+	RawBytes    int64                   `json:"rawBytes"`
+	SavedBytes  int64                   `json:"savedBytes"`
+	SavedRatio  float64                 `json:"savedRatio"` // 1 - stored/raw
+	Formats     map[string]*FormatStats `json:"formats"`
+}
+
+func (s *StorageStats) add(format byte, stored, raw int) {
+	f, ok := s.Formats[formatName(format)]
+	if !ok {
+		f = &FormatStats{}
+		s.Formats[formatName(format)] = f
+	}
+	f.Keys++
+	f.StoredBytes += int64(stored)
+	f.RawBytes += int64(raw)
+	s.Keys++
+	s.StoredBytes += int64(stored)
+	s.RawBytes += int64(raw)
+}
+
+func (s *StorageStats) finish() {
+	s.SavedBytes = s.RawBytes - s.StoredBytes
+	if s.RawBytes > 0 {
+		s.SavedRatio = float64(s.SavedBytes) / float64(s.RawBytes)
+	}
+}
+
+// 모든 데이터 키의 저장 크기와 원래 크기를 집계합니다
+func storageStats() (StorageStats, error) {
+	stats := StorageStats{Encoding: formatName(valueFormat), Formats: make(map[string]*FormatStats)}
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			if !isDataKey(item.Key()) {
+				continue
+			}
+			if err := item.Value(func(val []byte) error {
+				raw, err := decodeValue(val)
+				if err != nil {
+					return fmt.Errorf("%s: %w", item.Key(), err)
+				}
+				stats.add(storedFormat(val), len(val), len(raw))
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	stats.finish()
+	return stats, err
+}
+
+// 다른 형식으로 저장된 값을 현재 형식(valueFormat)으로 다시 저장하고, 바꾼 키 수를 반환합니다
+// 키마다 트랜잭션으로 읽고 다시 써서, 그 사이 다른 요청이 저장한 값을 덮어쓰지 않도록 합니다 (충돌 시 건너뜀)
+func migrateValues() (int, error) {
+	var keys [][]byte
+	err := db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			if isDataKey(it.Item().Key()) {
+				keys = append(keys, it.Item().KeyCopy(nil))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, key := range keys {
+		changed := false
+		err := db.Update(func(txn *badger.Txn) error {
+			item, err := txn.Get(key)
+			if err == badger.ErrKeyNotFound {
+				return nil // 그 사이 삭제됨
+			}
+			if err != nil {
+				return err
+			}
+			stored, err := item.ValueCopy(nil)
+			if err != nil || storedFormat(stored) == valueFormat {
+				return err
+			}
+			raw, err := decodeValue(stored)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			encoded := encodeValue(valueFormat, raw)
+			if bytes.Equal(encoded, stored) {
+				return nil
+			}
+			changed = true
+			return txn.SetEntry(newEntry(key, encoded).WithMeta(item.UserMeta())) // 검증 표시 유지
+		})
+		switch {
+		case err == badger.ErrConflict:
+			// 다른 요청이 먼저 저장함 (새 값은 이미 현재 형식)
+		case err != nil:
+			return migrated, err
+		case changed:
+			migrated++
+		}
+	}
+	return migrated, nil
+}
+
+var migrateMu sync.Mutex
+
+// /storage/stats : 저장 공간 사용량 (GET), /storage/migrate : 현재 형식으로 다시 저장 (POST)
+func storageStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	stats, err := storageStats()
+	if err != nil {
+		http.Error(w, "Failed to read storage stats", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
+
+func storageMigrateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	migrateMu.Lock()
+	defer migrateMu.Unlock()
+	migrated, err := migrateValues()
+	if err != nil {
+		http.Error(w, "Migration failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	stats, err := storageStats()
+	if err != nil {
+		http.Error(w, "Failed to read storage stats", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Migrated int          `json:"migrated"`
+		Stats    StorageStats `json:"stats"`
+	}{migrated, stats})
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+const testStockKey = "stock:20250428:KR7005930003"
+
+// initData 가 저장한 종목 JSON 을 읽습니다
+func testStockJSON(t *testing.T) []byte {
+	t.Helper()
+	var val []byte
+	err := db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(testStockKey))
+		if err != nil {
+			return err
+		}
+		stored, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		val, err = decodeValue(stored)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return val
+}
+
+func openTestDB(t *testing.T) {
+	t.Helper()
+	var err error
+	db, err = badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLoggingLevel(badger.WARNING))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+}
+
+func setValueFormat(t *testing.T, f byte) {
+	t.Helper()
+	old := valueFormat
+	valueFormat = f
+	t.Cleanup(func() { valueFormat = old })
+}
+
+func TestEncodeValueRoundTrip(t *testing.T) {
+	openTestDB(t)
+	initData()
+	stock := testStockJSON(t)
+
+	// protobuf 형식은 공백 없이 돌아오므로 공백을 뺀 원본과 비교
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, stock); err != nil {
+		t.Fatal(err)
+	}
+	for name, format := range valueEncodings {
+		encoded := encodeValue(format, stock)
+		if storedFormat(encoded) != format {
+			t.Errorf("%s: stored format = %s", name, formatName(storedFormat(encoded)))
+		}
+		if format != formatRaw && len(encoded) >= len(stock) {
+			t.Errorf("%s: %d bytes, raw %d bytes", name, len(encoded), len(stock))
+		}
+		decoded, err := decodeValue(encoded)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		want := stock
+		if format == formatProto || format == formatProtoZstd {
+			want = compact.Bytes()
+		}
+		if !bytes.Equal(decoded, want) {
+			t.Errorf("%s: decoded = %s", name, decoded)
+		}
+	}
+}
+
+func TestProtoFormatLossless(t *testing.T) {
+	// 2^53 보다 큰 정수, 소수/지수 표기, 정렬되지 않은 키, 이스케이프가 필요한 문자열이 그대로 돌아와야 함
+	doc := []byte(`{"zeta":9007199254740993,"alpha":-9223372036854775808,"price":1.50,"ratio":1e3,"neg":-0,` +
+		`"name":"삼성전자 <보통주> & \"우선주\"\n","nested":{"b":[1,2.0,null,true,false,{}],"a":[]},"empty":"",` +
+		`"big":123456789012345678901234567890,"padding":"` + strings.Repeat("x", 64) + `"}`)
+	for _, format := range []byte{formatProto, formatProtoZstd} {
+		encoded := encodeValue(format, doc)
+		if storedFormat(encoded) != format {
+			t.Errorf("%s: stored format = %s", formatName(format), formatName(storedFormat(encoded)))
+		}
+		decoded, err := decodeValue(encoded)
+		if err != nil {
+			t.Fatalf("%s: %v", formatName(format), err)
+		}
+		if !bytes.Equal(decoded, doc) {
+			t.Errorf("%s: decoded = %s", formatName(format), decoded)
+		}
+	}
+}
+
+func TestDecodeLegacyAndSmallValues(t *testing.T) {
+	// 헤더 없이 저장된 기존 JSON 은 그대로 읽음
+	legacy := []byte(`{"code":"KR7005930003"}`)
+	if storedFormat(legacy) != formatLegacy {
+		t.Errorf("legacy format = %s", formatName(storedFormat(legacy)))
+	}
+	if got, err := decodeValue(legacy); err != nil || !bytes.Equal(got, legacy) {
+		t.Errorf("legacy = %s, %v", got, err)
+	}
+
+	// 압축해도 줄지 않는 작은 값이나 JSON 객체가 아닌 값은 헤더 + 원본
+	for _, val := range [][]byte{[]byte("1"), []byte(`"hi"`), []byte("not json")} {
+		for name, format := range valueEncodings {
+			encoded := encodeValue(format, val)
+			if storedFormat(encoded) != formatRaw {
+				t.Errorf("%s(%s): format = %s", name, val, formatName(storedFormat(encoded)))
+			}
+			if got, err := decodeValue(encoded); err != nil || !bytes.Equal(got, val) {
+				t.Errorf("%s(%s): decoded = %s, %v", name, val, got, err)
+			}
+		}
+	}
+}
+
+func TestMigrateValues(t *testing.T) {
+	openTestDB(t)
+
+	// 기존 방식(헤더 없는 JSON)으로 저장된 데이터
+	setValueFormat(t, formatRaw)
+	initData()
+	stock := testStockJSON(t)
+	err := db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(testStockKey), stock)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := storageStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.Formats["legacy"] == nil || before.Formats["legacy"].Keys != 1 || before.SavedBytes > 0 {
+		t.Fatalf("before = %+v", before)
+	}
+
+	// 이전 형식과 섞여 있어도 모두 읽고, 마이그레이션 후에는 현재 형식으로 저장
+	valueFormat = formatZstd
+	migrated, err := migrateValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated < 1 {
+		t.Errorf("migrated = %d", migrated)
+	}
+	after, err := storageStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Formats["legacy"] != nil || after.Formats["zstd"] == nil {
+		t.Errorf("formats = %+v", after.Formats)
+	}
+	err = db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(testStockKey))
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			if storedFormat(val) != formatZstd {
+				t.Errorf("stock format = %s", formatName(storedFormat(val)))
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.RawBytes != before.RawBytes || after.SavedRatio <= 0 {
+		t.Errorf("after = %+v", after)
+	}
+	if got, err := loadValue(testStockKey); err != nil || got != string(stock) {
+		t.Errorf("loadValue = %s, %v", got, err)
+	}
+
+	// 이미 현재 형식이면 다시 쓰지 않음
+	if migrated, err := migrateValues(); err != nil || migrated != 0 {
+		t.Errorf("second migration = %d, %v", migrated, err)
+	}
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// 검증에 실패한 스냅샷의 처리 방식 (-validation)
+const (
+	validationOff    = "off"    // 검증하지 않음
+	validationReject = "reject" // 저장하지 않고 에러 반환
+	validationWarn   = "warn"   // 로그를 남기고 저장
+	validationFlag   = "flag"   // 저장하고 메타데이터(UserMeta)와 위반 내용을 함께 기록
+)
+
+var validationModes = map[string]bool{validationOff: true, validationReject: true, validationWarn: true, validationFlag: true}
+
+var validationMode = validationWarn
+
+// 검증에 실패해 flag 로 저장된 값의 UserMeta
+const metaInvalid byte = 0x01
+
+// flag 모드의 위반 내용 키 형식 (validation:<원래 키>)
+const validationKeyPrefix = "validation:"
+
+// ETF/ETN 등 호가 단위가 5원인 증권 그룹
+var fundStockGroups = map[string]bool{"EF": true, "EN": true, "FE": true}
+
+// KRX 주식 호가 단위 (유가증권/코스닥 공통, 가격 미만 기준)
+var tickTable = []struct{ below, tick int64 }{
+	{2000, 1},
+	{5000, 5},
+	{20000, 10},
+	{50000, 50},
+	{200000, 100},
+	{500000, 500},
+}
+
+func tickSize(price int64, stockGroup string) int64 {
+	if fundStockGroups[stockGroup] {
+		return 5
+	}
+	for _, t := range tickTable {
+		if price < t.below {
+			return t.tick
+		}
+	}
+	return 1000
+}
+
+// Violation 은 스냅샷 검증 규칙 위반 한 건입니다
+type Violation struct {
+	Field   string `json:"field"`
+	Value   int64  `json:"value"`
+	Rule    string `json:"rule"` // limit, tick, order, type
+	Message string `json:"message"`
+}
+
+// ValidationError 는 reject 모드에서 저장을 거부한 이유입니다
+type ValidationError struct {
+	Key        string
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Message
+	}
+	return fmt.Sprintf("invalid snapshot %s: %s", e.Key, strings.Join(msgs, "; "))
+}
+
+// 검증할 가격 필드
+type priceSnapshot struct {
+	Code       string `json:"code"`
+	StockGroup string `json:"stockGroupId"`
+	Open       int64  `json:"open"`
+	High       int64  `json:"high"`
+	Low        int64  `json:"low"`
+	Close      int64  `json:"close"`
+	Upper      int64  `json:"upperLimitPrice"`
+	Lower      int64  `json:"lowerLimitPrice"`
+	ASOpen     int64  `json:"afterSingleOpen"`
+	ASHigh     int64  `json:"afterSingleHigh"`
+	ASLow      int64  `json:"afterSingleLow"`
+	ASClose    int64  `json:"afterSingleClose"`
+	ASUpper    int64  `json:"afterSingleUpperLimitPrice"`
+	ASLower    int64  `json:"afterSingleLowerLimitPrice"`
+}
+
+// 시가/고가/저가/종가 한 묶음을 가격 제한폭, 호가 단위, 크기 순서로 검증합니다
+// 0 은 체결이 없다는 뜻이므로 검사하지 않고, 제한폭이 0 이면 제한폭 검사를 건너뜁니다
+func checkOHLC(prefix string, open, high, low, close, lower, upper int64, stockGroup string) []Violation {
+	// 필드 이름 (open, afterSingleOpen 등)
+	fieldName := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + strings.ToUpper(name[:1]) + name[1:]
+	}
+
+	var vs []Violation
+	prices := []struct {
+		name  string
+		value int64
+	}{{"open", open}, {"high", high}, {"low", low}, {"close", close}}
+	for _, p := range prices {
+		field := fieldName(p.name)
+		if p.value == 0 {
+			continue
+		}
+		if lower > 0 && upper > 0 && (p.value < lower || p.value > upper) {
+			vs = append(vs, Violation{field, p.value, "limit", fmt.Sprintf("%s %d is outside %d..%d", field, p.value, lower, upper)})
+		}
+		if tick := tickSize(p.value, stockGroup); p.value%tick != 0 {
+			vs = append(vs, Violation{field, p.value, "tick", fmt.Sprintf("%s %d is not a multiple of tick %d", field, p.value, tick)})
+		}
+	}
+	if open == 0 || high == 0 || low == 0 || close == 0 {
+		return vs
+	}
+	if high < max(open, close) {
+		vs = append(vs, Violation{fieldName("high"), high, "order", fmt.Sprintf("%s %d is below open/close", fieldName("high"), high)})
+	}
+	if low > min(open, close) {
+		vs = append(vs, Violation{fieldName("low"), low, "order", fmt.Sprintf("%s %d is above open/close", fieldName("low"), low)})
+	}
+	return vs
+}
+
+// 종목 스냅샷의 가격을 검증합니다 (스냅샷이 아닌 값은 검사하지 않음)
+// 가격을 정수로 읽을 수 없는 값(문자열, 소수 등)은 검사를 건너뛰지 않고 위반으로 봅니다
+func validateSnapshot(key, val []byte) []Violation {
+	if !strings.HasPrefix(string(key), stockKeyPrefix) {
+		return nil
+	}
+	var s priceSnapshot
+	if err := json.Unmarshal(val, &s); err != nil {
+		var terr *json.UnmarshalTypeError
+		if errors.As(err, &terr) && terr.Field != "" {
+			return []Violation{{Field: terr.Field, Rule: "type", Message: fmt.Sprintf("%s must be an integer, got %s", terr.Field, terr.Value)}}
+		}
+		return []Violation{{Rule: "type", Message: fmt.Sprintf("snapshot is not a JSON object: %v", err)}}
+	}
+	if s.Code == "" {
+		return nil
+	}
+	vs := checkOHLC("", s.Open, s.High, s.Low, s.Close, s.Lower, s.Upper, s.StockGroup)
+	return append(vs, checkOHLC("afterSingle", s.ASOpen, s.ASHigh, s.ASLow, s.ASClose, s.ASLower, s.ASUpper, s.StockGroup)...)
+}
+
+// 쓰기 전에 스냅샷을 검증하고 validationMode 에 따라 처리합니다
+// reject 이면 *ValidationError 를 반환하고, flag 이면 저장할 값의 UserMeta 와 위반 내용(validation:<키>)을 기록합니다
+func checkSnapshot(txn *badger.Txn, key, val []byte) (byte, error) {
+	if validationMode == validationOff {
+		return 0, nil
+	}
+	vs := validateSnapshot(key, val)
+	switch {
+	case validationMode == validationFlag:
+		vkey := []byte(validationKeyPrefix + string(key))
+		if len(vs) == 0 {
+			// 이전에 기록한 위반 내용이 있으면 지움
+			if _, err := txn.Get(vkey); err == nil {
+				return 0, txn.Delete(vkey)
+			} else if err != badger.ErrKeyNotFound {
+				return 0, err
+			}
+			return 0, nil
+		}
+		data, err := json.Marshal(vs)
+		if err != nil {
+			return 0, err
+		}
+		return metaInvalid, txn.SetEntry(newEntry(vkey, encodeValue(valueFormat, data)))
+	case len(vs) == 0:
+		return 0, nil
+	case validationMode == validationReject:
+		return 0, &ValidationError{Key: string(key), Violations: vs}
+	default:
+		log.Printf("validation: %s", (&ValidationError{Key: string(key), Violations: vs}).Error())
+		return 0, nil
+	}
+}
+
+// /validation?key=... : 값의 검증 결과 (flag 모드로 저장된 위반 내용)
+func validationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "Missing 'key' parameter", http.StatusBadRequest)
+		return
+	}
+
+	resp := struct {
+		Key        string      `json:"key"`
+		Flagged    bool        `json:"flagged"`
+		Violations []Violation `json:"violations"`
+	}{Key: key, Violations: []Violation{}}
+	err := db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+		resp.Flagged = item.UserMeta()&metaInvalid != 0
+		vitem, err := txn.Get([]byte(validationKeyPrefix + key))
+		if err == badger.ErrKeyNotFound || !resp.Flagged {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return vitem.Value(func(val []byte) error {
+			raw, err := decodeValue(val)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(raw, &resp.Violations)
+		})
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		http.Error(w, "Key not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to read validation result", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
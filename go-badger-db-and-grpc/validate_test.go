@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestTickSize(t *testing.T) {
+	for _, c := range []struct {
+		price int64
+		group string
+		want  int64
+	}{
+		{1999, "ST", 1}, {2000, "ST", 5}, {19990, "ST", 10}, {49950, "ST", 50},
+		{199900, "ST", 100}, {499500, "ST", 500}, {500000, "ST", 1000}, {49005, "EF", 5},
+	} {
+		if got := tickSize(c.price, c.group); got != c.want {
+			t.Errorf("tickSize(%d, %s) = %d, want %d", c.price, c.group, got, c.want)
+		}
+	}
+}
+
+func TestValidateSnapshot(t *testing.T) {
+	openTestDB(t)
+	initData()
+	if vs := validateSnapshot([]byte(testStockKey), testStockJSON(t)); len(vs) != 0 {
+		t.Errorf("initData violations = %+v", vs)
+	}
+
+	doc := `{"code":"KR7005930003","stockGroupId":"ST",
+		"open":49000,"high":48900,"low":49100,"close":90000,"lowerLimitPrice":48500,"upperLimitPrice":89900,
+		"afterSingleClose":54010,"afterSingleLowerLimitPrice":48500,"afterSingleUpperLimitPrice":53900}`
+	var got []string
+	for _, v := range validateSnapshot([]byte(testStockKey), []byte(doc)) {
+		got = append(got, v.Field+":"+v.Rule)
+	}
+	sort.Strings(got)
+	want := []string{"afterSingleClose:limit", "afterSingleClose:tick", "close:limit", "high:order", "low:order"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("violations = %v, want %v", got, want)
+	}
+
+	// 정수로 읽을 수 없는 가격이나 객체가 아닌 값은 검사를 건너뛰지 않고 위반으로 봄
+	for _, bad := range []string{
+		`{"code":"KR7005930003","close":"90000"}`,
+		`{"code":"KR7005930003","close":90000.5}`,
+		`{"code":"KR7005930003","afterSingleHigh":1e3}`,
+		`[1,2,3]`,
+		`not json`,
+	} {
+		if vs := validateSnapshot([]byte(testStockKey), []byte(bad)); len(vs) != 1 || vs[0].Rule != "type" {
+			t.Errorf("%s: violations = %+v", bad, vs)
+		}
+	}
+
+	// 종목 스냅샷이 아닌 값은 검사하지 않음
+	if vs := validateSnapshot([]byte("other"), []byte(doc)); len(vs) != 0 {
+		t.Errorf("non-stock key violations = %+v", vs)
+	}
+}
+
+func TestValidationModes(t *testing.T) {
+	openTestDB(t)
+	old := validationMode
+	t.Cleanup(func() { validationMode = old })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/set", setHandler)
+	mux.HandleFunc("/get", getHandler)
+	mux.HandleFunc("/validation", validationHandler)
+	do := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		var b bytes.Buffer
+		json.NewEncoder(&b).Encode(body)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, &b))
+		return rec
+	}
+	set := func(key, value string) int {
+		return do(http.MethodPost, "/set", KeyValue{Key: key, Value: value}).Code
+	}
+	invalid := `{"code":"KR7005930003","open":49000,"high":49050,"low":49000,"close":49010,"lowerLimitPrice":48500,"upperLimitPrice":89900}`
+	valid := `{"code":"KR7005930003","open":49000,"high":49000,"low":49000,"close":49000,"lowerLimitPrice":48500,"upperLimitPrice":89900}`
+
+	// reject: 저장하지 않고 422
+	validationMode = validationReject
+	if code := set("stock:20250429:A", invalid); code != http.StatusUnprocessableEntity {
+		t.Errorf("reject: status %d", code)
+	}
+	if code := do(http.MethodGet, "/get?key=stock:20250429:A", nil).Code; code != http.StatusNotFound {
+		t.Errorf("reject: stored (status %d)", code)
+	}
+	// 가격을 읽을 수 없는 값도 검증을 건너뛰지 않고 거부
+	if code := set("stock:20250429:A", `{"code":"KR7005930003","close":"49010"}`); code != http.StatusUnprocessableEntity {
+		t.Errorf("reject string price: status %d", code)
+	}
+
+	// warn: 저장
+	validationMode = validationWarn
+	if code := set("stock:20250429:B", invalid); code != http.StatusOK {
+		t.Errorf("warn: status %d", code)
+	}
+
+	// flag: 저장하고 위반 내용 기록, 올바른 값으로 다시 저장하면 지움
+	validationMode = validationFlag
+	if code := set("stock:20250429:C", invalid); code != http.StatusOK {
+		t.Errorf("flag: status %d", code)
+	}
+	var result struct {
+		Flagged    bool        `json:"flagged"`
+		Violations []Violation `json:"violations"`
+	}
+	check := func(flagged bool, violations int) {
+		t.Helper()
+		rec := do(http.MethodGet, "/validation?key=stock:20250429:C", nil)
+		if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+			t.Fatalf("%v: %s", err, rec.Body)
+		}
+		if result.Flagged != flagged || len(result.Violations) != violations {
+			t.Errorf("validation = %+v, want flagged %t with %d violations", result, flagged, violations)
+		}
+	}
+	check(true, 1)
+	if result.Violations[0].Field != "close" || result.Violations[0].Rule != "tick" {
+		t.Errorf("violation = %+v", result.Violations[0])
+	}
+
+	// 저장 형식을 바꿔도 표시가 유지됨
+	setValueFormat(t, formatSnappy)
+	if _, err := migrateValues(); err != nil {
+		t.Fatal(err)
+	}
+	check(true, 1)
+
+	if code := set("stock:20250429:C", valid); code != http.StatusOK {
+		t.Errorf("flag valid: status %d", code)
+	}
+	check(false, 0)
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	bpb "github.com/dgraph-io/badger/v4/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+// VI 이벤트 키 형식 (vi:<ISIN>:<YYYYMMDD>:<unix 나노초 8바이트>:<trigger|clear>)
+const viKeyPrefix = "vi:"
+
+// VI 이벤트 종류
+const (
+	viTrigger = "trigger" // 발동
+	viClear   = "clear"   // 해제
+)
+
+var dateRe = regexp.MustCompile(`^\d{8}$`)
+
+// 종목 문서의 VI 상태
+type viState struct {
+	Code             string  `json:"code"`
+	BaseDate         string  `json:"baseDate"`
+	ApplyCode        string  `json:"viApplyCode"`
+	TriggerCount     int64   `json:"viTriggerCount"`
+	TriggerTime      string  `json:"viTriggerTime"`
+	ClearTime        string  `json:"viClearTime"`
+	Kind             string  `json:"viKind"`
+	TriggerPrice     int64   `json:"viTriggerPrice"`
+	StaticBasePrice  int64   `json:"staticVITrgBasePrice"`
+	DynamicBasePrice int64   `json:"dynamicVITrgBasePrice"`
+	StaticGapRate    float64 `json:"staticVITriggerPriceGapRate"`
+	DynamicGapRate   float64 `json:"dynamicVITriggerPriceGapRate"`
+}
+
+// VIEvent 는 VI 발동 또는 해제 한 건입니다
+type VIEvent struct {
+	ISIN             string    `json:"isin"`
+	Date             string    `json:"date"`
+	Type             string    `json:"type"` // trigger, clear
+	Time             time.Time `json:"time"`
+	Kind             string    `json:"kind"`      // viKind
+	ApplyCode        string    `json:"applyCode"` // viApplyCode
+	TriggerCount     int64     `json:"triggerCount"`
+	TriggerPrice     int64     `json:"triggerPrice"`
+	StaticBasePrice  int64     `json:"staticBasePrice"`
+	DynamicBasePrice int64     `json:"dynamicBasePrice"`
+	StaticGapRate    float64   `json:"staticGapRate"`
+	DynamicGapRate   float64   `json:"dynamicGapRate"`
+}
+
+func viKey(isin, date string, t time.Time, typ string) []byte {
+	key := []byte(viKeyPrefix + isin + ":" + date + ":")
+	key = binary.BigEndian.AppendUint64(key, uint64(t.UnixNano()))
+	return append(key, ":"+typ...)
+}
+
+// 이전 상태와 비교해 새로 발동되거나 해제된 VI 를 찾습니다
+// 처음 저장하는 문서는 VI 가 없던 상태와 비교하며, 같은 이벤트는 키가 같으므로 다시 저장해도 한 건입니다
+func viTransitions(prev, cur viState) []VIEvent {
+	event := func(typ, at string) (VIEvent, bool) {
+		t, err := time.ParseInLocation("20060102 15:04:05.999999999", cur.BaseDate+" "+at, seoul)
+		if err != nil {
+			return VIEvent{}, false
+		}
+		return VIEvent{
+			ISIN:             cur.Code,
+			Date:             cur.BaseDate,
+			Type:             typ,
+			Time:             t,
+			Kind:             cur.Kind,
+			ApplyCode:        cur.ApplyCode,
+			TriggerCount:     cur.TriggerCount,
+			TriggerPrice:     cur.TriggerPrice,
+			StaticBasePrice:  cur.StaticBasePrice,
+			DynamicBasePrice: cur.DynamicBasePrice,
+			StaticGapRate:    cur.StaticGapRate,
+			DynamicGapRate:   cur.DynamicGapRate,
+		}, true
+	}
+
+	var events []VIEvent
+	if cur.TriggerTime != "" && (cur.TriggerTime != prev.TriggerTime || cur.TriggerCount > prev.TriggerCount) {
+		if e, ok := event(viTrigger, cur.TriggerTime); ok {
+			events = append(events, e)
+		}
+	}
+	if cur.ClearTime != "" && cur.ClearTime != prev.ClearTime {
+		if e, ok := event(viClear, cur.ClearTime); ok {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// 종목 문서를 덮어쓰기 전에 저장된 문서와 VI 상태를 비교해, 바뀐 내용을 이벤트 로그에 저장합니다
+func recordVIEvents(txn *badger.Txn, key, val []byte) error {
+	if !strings.HasPrefix(string(key), stockKeyPrefix) {
+		return nil
+	}
+	var cur viState
+	if err := json.Unmarshal(val, &cur); err != nil || cur.Code == "" || !dateRe.MatchString(cur.BaseDate) {
+		return nil
+	}
+
+	var prev viState
+	item, err := txn.Get(key)
+	switch {
+	case err == badger.ErrKeyNotFound:
+	case err != nil:
+		return err
+	default:
+		if err := item.Value(func(stored []byte) error {
+			raw, err := decodeValue(stored)
+			if err == nil && json.Unmarshal(raw, &prev) == nil && prev.BaseDate != cur.BaseDate {
+				prev = viState{} // 다른 거래일 문서의 VI 상태는 비교하지 않음
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, e := range viTransitions(prev, cur) {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if err := txn.SetEntry(newEntry(viKey(e.ISIN, e.Date, e.Time, e.Type), encodeValue(valueFormat, data))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeVIEvent(stored []byte) (VIEvent, error) {
+	var e VIEvent
+	raw, err := decodeValue(stored)
+	if err != nil {
+		return e, err
+	}
+	err = json.Unmarshal(raw, &e)
+	return e, err
+}
+
+// 종목의 거래일 VI 이벤트를 시간 순서로 반환합니다
+func listVIEvents(isin, date string) ([]VIEvent, error) {
+	events := []VIEvent{}
+	prefix := []byte(viKeyPrefix + isin + ":" + date + ":")
+	err := db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{Prefix: prefix, PrefetchValues: true, PrefetchSize: 100})
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			if err := it.Item().Value(func(val []byte) error {
+				e, err := decodeVIEvent(val)
+				if err != nil {
+					return fmt.Errorf("%s: %w", it.Item().Key(), err)
+				}
+				events = append(events, e)
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return events, err
+}
+
+// 새로 저장되는 VI 이벤트를 fn 으로 전달합니다 (isin 을 비우면 모든 종목, ctx 가 끝날 때까지 실행)
+func watchVIEvents(ctx context.Context, isin string, fn func(VIEvent) error) error {
+	prefix := viKeyPrefix
+	if isin != "" {
+		prefix += isin + ":"
+	}
+	return db.Subscribe(ctx, func(kvs *badger.KVList) error {
+		for _, kv := range kvs.Kv {
+			e, err := decodeVIEvent(kv.Value)
+			if err != nil {
+				continue // 삭제 등 이벤트가 아닌 변경
+			}
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, []bpb.Match{{Prefix: []byte(prefix)}})
+}
+
+// 날짜(YYYYMMDD) 파라미터를 확인합니다 (비우면 한국 시간 오늘)
+func parseDateParam(date string) (string, error) {
+	if date == "" {
+		return time.Now().In(seoul).Format("20060102"), nil
+	}
+	if !dateRe.MatchString(date) {
+		return "", fmt.Errorf("invalid date %q (YYYYMMDD)", date)
+	}
+	return date, nil
+}
+
+// /vi/{isin}/events?date=20250429 : 거래일의 VI 발동/해제 이력
+func viEventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	date, err := parseDateParam(r.URL.Query().Get("date"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+	events, err := listVIEvents(r.PathValue("isin"), date)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to load VI events"})
+		return
+	}
+	json.NewEncoder(w).Encode(events)
+}
+
+// /vi/stream?isin=KR7005930003 : 새 VI 이벤트를 Server-Sent Events 로 전달
+func viStreamHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET method allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	watchVIEvents(r.Context(), r.URL.Query().Get("isin"), func(e VIEvent) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: vi\ndata: %s\n\n", data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	})
+}
+
+func (e VIEvent) proto() *pb.VIEvent {
+	return &pb.VIEvent{
+		Isin:             e.ISIN,
+		Date:             e.Date,
+		Type:             e.Type,
+		Time:             e.Time.Format(time.RFC3339Nano),
+		Kind:             e.Kind,
+		ApplyCode:        e.ApplyCode,
+		TriggerCount:     e.TriggerCount,
+		TriggerPrice:     e.TriggerPrice,
+		StaticBasePrice:  e.StaticBasePrice,
+		DynamicBasePrice: e.DynamicBasePrice,
+		StaticGapRate:    e.StaticGapRate,
+		DynamicGapRate:   e.DynamicGapRate,
+	}
+}
+
+func (s *stockServer) ListVIEvents(ctx context.Context, req *pb.ListVIEventsRequest) (*pb.VIEvents, error) {
+	date, err := parseDateParam(req.Date)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	events, err := listVIEvents(req.Isin, date)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.VIEvents{Isin: req.Isin, Date: date, Events: make([]*pb.VIEvent, len(events))}
+	for i, e := range events {
+		resp.Events[i] = e.proto()
+	}
+	return resp, nil
+}
+
+func (s *stockServer) WatchVIEvents(req *pb.WatchVIEventsRequest, stream pb.StockService_WatchVIEventsServer) error {
+	err := watchVIEvents(stream.Context(), req.Isin, func(e VIEvent) error {
+		return stream.Send(e.proto())
+	})
+	if stream.Context().Err() != nil {
+		return nil // 클라이언트가 구독을 끝냄
+	}
+	return err
+}
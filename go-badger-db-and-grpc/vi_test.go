@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/yiminan/go-examples/go-badger-db-and-grpc/proto/generated"
+)
+
+// VI 상태만 담은 종목 문서를 저장합니다
+func putVIState(t *testing.T, count int, triggerTime, clearTime string) {
+	t.Helper()
+	doc := fmt.Sprintf(`{"code":"KR7000660001","baseDate":"20250429","viApplyCode":"1","viKind":"2","viTriggerCount":%d,"viTriggerTime":%q,"viClearTime":%q,"viTriggerPrice":200000}`,
+		count, triggerTime, clearTime)
+	err := db.Update(func(txn *badger.Txn) error {
+		return putValue(txn, []byte("stock:20250429:KR7000660001"), []byte(doc))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVIEventLog(t *testing.T) {
+	openTestDB(t)
+	putVIState(t, 0, "", "")
+	putVIState(t, 1, "09:10:00.1", "")           // 발동
+	putVIState(t, 1, "09:10:00.1", "")           // 변화 없음
+	putVIState(t, 1, "09:10:00.1", "09:12:00.2") // 해제
+	putVIState(t, 2, "10:00:00.3", "10:02:00.4") // 스냅샷 사이에 발동과 해제
+
+	events, err := listVIEvents("KR7000660001", "20250429")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"trigger 09:10:00 1", "clear 09:12:00 1", "trigger 10:00:00 2", "clear 10:02:00 2"}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v", events)
+	}
+	for i, e := range events {
+		if got := fmt.Sprintf("%s %s %d", e.Type, e.Time.In(seoul).Format("15:04:05"), e.TriggerCount); got != want[i] {
+			t.Errorf("event %d = %s, want %s", i, got, want[i])
+		}
+	}
+
+	// 처음 저장된 문서의 VI 도 기록 (initData: 15:44:06 발동, 15:46:12 해제)
+	initData()
+	events, err = listVIEvents("KR7005930003", "20250429")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Type != viTrigger || events[0].TriggerPrice != 52700 || events[1].Type != viClear {
+		t.Errorf("initData events = %+v", events)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vi/{isin}/events", viEventsHandler)
+	for path, want := range map[string]int{
+		"/vi/KR7005930003/events?date=20250429":   http.StatusOK,
+		"/vi/KR7005930003/events?date=2025-04-29": http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Errorf("%s: status %d, want %d (%s)", path, rec.Code, want, rec.Body)
+		}
+	}
+}
+
+func TestWatchVIEvents(t *testing.T) {
+	openTestDB(t)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterStockServiceServer(grpcServer, &stockServer{})
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := pb.NewStockServiceClient(conn).WatchVIEvents(ctx, &pb.WatchVIEventsRequest{Isin: "KR7000660001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 구독이 등록되기 전의 변경은 받을 수 없으므로, 받을 때까지 새로 발동된 VI 를 저장
+	received := make(chan *pb.VIEvent, 1)
+	go func() {
+		if e, err := stream.Recv(); err == nil {
+			received <- e
+		}
+	}()
+	for n := 1; ; n++ {
+		putVIState(t, n, fmt.Sprintf("09:%02d:%02d.0", n/60%60, n%60), "")
+		select {
+		case e := <-received:
+			if e.Isin != "KR7000660001" || e.Type != viTrigger || e.TriggerCount < 1 {
+				t.Errorf("event = %v", e)
+			}
+			return
+		case <-time.After(20 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatal("no VI event received")
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// kvbench 는 KV 엔진 벤치마크 시나리오를 실행하는 CLI 입니다
+//
+// 플래그로 시나리오 하나를 실행하거나, -config 로 YAML 시나리오 파일을 지정해 여러 시나리오를 실행합니다.
+// 결과는 사람이 읽는 표(-format table) 또는 JSON(-format json)으로 출력하고,
+// -baseline 을 지정하면 기준 결과와 비교해 처리량이 허용 범위 이상 떨어진 경우 0이 아닌 코드로 종료합니다.
+//
+//	go run ./cmd/kvbench -engine pebble -type mixed -read-ratio 0.8 -duration 30s -warmup 5s
+//	go run ./cmd/kvbench -config cmd/kvbench/scenarios.yaml -format json -out current.json -baseline baseline.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/yiminan/go-examples/go-badger-db/kvbench"
+	"gopkg.in/yaml.v3"
+)
+
+// scenario 는 실행할 벤치마크 시나리오 하나입니다
+// YAML 파일에서 지정하지 않은 항목은 플래그 값을 기본값으로 사용합니다
+type scenario struct {
+	Name          string        `yaml:"name"`
+	Engine        string        `yaml:"engine"`
+	Type          string        `yaml:"type"`     // write, read, mixed, ycsb
+	Workload      string        `yaml:"workload"` // ycsb 유형의 워크로드 (A~F)
+	Ops           int           `yaml:"ops"`
+	Workers       int           `yaml:"workers"`       // 0 이면 CPU 코어 수
+	WorkersPerCPU int           `yaml:"workersPerCPU"` // 지정하면 CPU 코어 수의 배수로 워커 수 결정
+	KeySize       int           `yaml:"keySize"`
+	ValueSize     int           `yaml:"valueSize"`
+	Sync          bool          `yaml:"sync"`
+	InMemory      bool          `yaml:"inMemory"`
+	Mode          string        `yaml:"mode"`
+	BatchSize     int           `yaml:"batchSize"`
+	ReadRatio     float64       `yaml:"readRatio"`
+	Duration      time.Duration `yaml:"duration"` // 0 이면 ops 만큼 실행
+	Warmup        time.Duration `yaml:"warmup"`
+	KeyDist       string        `yaml:"keyDist"`
+	ValueDist     string        `yaml:"valueDist"`
+	ValueMin      int           `yaml:"valueMin"`
+	ValueMax      int           `yaml:"valueMax"`
+}
+
+// scenarioFile 은 -config 로 읽는 YAML 파일 형식입니다
+type scenarioFile struct {
+	Scenarios []yaml.Node `yaml:"scenarios"`
+}
+
+func main() {
+	var base scenario
+	flag.StringVar(&base.Name, "name", "", "시나리오 이름 (비우면 유형과 쓰기 방식으로 생성)")
+	flag.StringVar(&base.Engine, "engine", kvbench.EngineBadger, "엔진 (badger, pebble)")
+	flag.StringVar(&base.Type, "type", "write", "테스트 유형 (write, read, mixed, ycsb)")
+	flag.StringVar(&base.Workload, "workload", "A", "ycsb 유형의 워크로드 (A~F)")
+	flag.IntVar(&base.Ops, "ops", 1000000, "작업 수 (읽기/혼합/ycsb 는 10%를 미리 로드)")
+	flag.IntVar(&base.Workers, "workers", 0, "워커 고루틴 수 (0 이면 CPU 코어 수)")
+	flag.IntVar(&base.KeySize, "key-size", 16, "키 크기 (bytes)")
+	flag.IntVar(&base.ValueSize, "value-size", 100, "값 크기 (bytes)")
+	flag.BoolVar(&base.Sync, "sync", false, "동기 쓰기 여부")
+	flag.BoolVar(&base.InMemory, "in-memory", false, "인메모리 모드 여부")
+	flag.StringVar(&base.Mode, "mode", kvbench.WriteModeIndividual, "쓰기 방식 (individual, batch, writebatch, stream)")
+	flag.IntVar(&base.BatchSize, "batch-size", 0, "batch/stream 모드의 배치 크기")
+	flag.Float64Var(&base.ReadRatio, "read-ratio", 0.5, "mixed 유형의 읽기 비율 (0~1)")
+	flag.DurationVar(&base.Duration, "duration", 0, "지정하면 작업 수 대신 이 시간 동안 실행")
+	flag.DurationVar(&base.Warmup, "warmup", 0, "측정 전 워밍업 시간")
+	flag.StringVar(&base.KeyDist, "key-dist", "", "키 분포 (uniform, zipfian, latest, hotspot)")
+	flag.StringVar(&base.ValueDist, "value-dist", kvbench.ValueDistFixed, "값 크기 분포 (fixed, uniform, zipfian)")
+	flag.IntVar(&base.ValueMin, "value-min", 100, "값 크기 분포의 최소 크기 (bytes)")
+	flag.IntVar(&base.ValueMax, "value-max", 1000, "값 크기 분포의 최대 크기 (bytes)")
+
+	configPath := flag.String("config", "", "YAML 시나리오 파일 (지정하면 파일의 시나리오를 모두 실행)")
+	format := flag.String("format", "table", "출력 형식 (table, json)")
+	outPath := flag.String("out", "", "JSON 결과를 저장할 파일 (비우면 json 형식일 때 stdout 에 출력)")
+	timeSeries := flag.Bool("timeseries", false, "초당 처리량 시계열 기록 여부")
+	baselinePath := flag.String("baseline", "", "비교할 기준 결과 JSON 파일")
+	maxRegression := flag.Float64("max-regression", 0.1, "기준 대비 허용하는 처리량 감소 비율 (0.1 = 10%)")
+	flag.Parse()
+
+	if *format != "table" && *format != "json" {
+		log.Fatalf("알 수 없는 출력 형식: %s", *format)
+	}
+
+	scenarios := []scenario{base}
+	if *configPath != "" {
+		var err error
+		if scenarios, err = loadScenarios(*configPath, base); err != nil {
+			log.Fatalf("시나리오 파일 로드 실패: %v", err)
+		}
+	}
+
+	// JSON 을 stdout 에 출력할 때는 진행 상황 출력을 stderr 로 보내 JSON 이 깨지지 않도록 함
+	stdout := os.Stdout
+	if *format == "json" && *outPath == "" {
+		os.Stdout = os.Stderr
+	}
+
+	var results []kvbench.Result
+	for _, sc := range scenarios {
+		result, err := runScenario(sc, *format == "table", *timeSeries)
+		if err != nil {
+			log.Fatalf("%s: %v", sc.label(), err)
+		}
+		results = append(results, result)
+	}
+	os.Stdout = stdout
+
+	switch {
+	case *format == "table":
+		kvbench.PrintComparisonTable(results)
+	case *outPath == "":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			log.Fatalf("JSON 출력 실패: %v", err)
+		}
+	}
+	if *outPath != "" {
+		if err := kvbench.WriteResultsJSON(*outPath, results); err != nil {
+			log.Fatalf("JSON 결과 저장 실패: %v", err)
+		}
+	}
+
+	if *baselinePath == "" {
+		return
+	}
+	baseline, err := readResults(*baselinePath)
+	if err != nil {
+		log.Fatalf("기준 결과 로드 실패: %v", err)
+	}
+	regressions := compareBaseline(baseline, results, *maxRegression)
+	for _, r := range regressions {
+		fmt.Fprintln(os.Stderr, r)
+	}
+	if len(regressions) > 0 {
+		os.Exit(1)
+	}
+}
+
+// YAML 시나리오 파일을 읽습니다 (각 시나리오는 base 값 위에 덮어씀)
+func loadScenarios(path string, base scenario) ([]scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file scenarioFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true) // 오타난 항목이 조용히 무시되지 않도록 함
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(file.Scenarios) == 0 {
+		return nil, fmt.Errorf("%s: 시나리오가 없습니다", path)
+	}
+
+	scenarios := make([]scenario, 0, len(file.Scenarios))
+	for i := range file.Scenarios {
+		sc := base
+		sc.Name = ""
+		if err := decodeStrict(&file.Scenarios[i], &sc); err != nil {
+			return nil, fmt.Errorf("%s: %d번째 시나리오: %w", path, i+1, err)
+		}
+		scenarios = append(scenarios, sc)
+	}
+	return scenarios, nil
+}
+
+// 노드를 모르는 항목 없이 디코딩합니다 (yaml.Node.Decode 는 KnownFields 를 지원하지 않아 다시 인코딩해서 읽음)
+func decodeStrict(node *yaml.Node, v interface{}) error {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	return dec.Decode(v)
+}
+
+// 결과에 표시할 시나리오 이름 (이름을 지정하지 않으면 유형과 쓰기 방식으로 생성)
+func (sc scenario) label() string {
+	switch {
+	case sc.Name != "":
+		return sc.Name
+	case sc.Type == "ycsb":
+		return "YCSB-" + sc.Workload
+	default:
+		return sc.Type + "/" + sc.Mode
+	}
+}
+
+// 시나리오 하나를 실행하고 결과를 반환합니다
+func runScenario(sc scenario, printResults, timeSeries bool) (kvbench.Result, error) {
+	workers := sc.Workers
+	if sc.WorkersPerCPU > 0 {
+		workers = runtime.NumCPU() * sc.WorkersPerCPU
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	name := sc.label()
+
+	test, err := kvbench.NewIndividualWriteTest(sc.Engine, sc.Ops, workers, sc.KeySize, sc.ValueSize, sc.Sync, sc.InMemory)
+	if err != nil {
+		return kvbench.Result{}, fmt.Errorf("테스트 초기화 실패: %w", err)
+	}
+	defer test.Cleanup()
+
+	test.SetRecordTimeSeries(timeSeries)
+	test.SetDuration(sc.Duration)
+	test.SetWarmup(sc.Warmup)
+	if err := test.SetWriteMode(sc.Mode, sc.BatchSize); err != nil {
+		return kvbench.Result{}, err
+	}
+	if err := test.SetValueSizeDistribution(sc.ValueDist, sc.ValueMin, sc.ValueMax); err != nil {
+		return kvbench.Result{}, err
+	}
+	if sc.KeyDist != "" {
+		if err := test.SetKeyDistribution(sc.KeyDist, sc.Ops/10); err != nil {
+			return kvbench.Result{}, err
+		}
+	}
+
+	fmt.Printf("\n=== 시나리오 시작: %s (%s) ===\n", name, sc.Engine)
+	var elapsed time.Duration
+	if sc.Type == "ycsb" {
+		w, err := kvbench.YCSBPreset(sc.Workload)
+		if err != nil {
+			return kvbench.Result{}, err
+		}
+		elapsed, err = test.RunWorkload(w)
+		if err != nil {
+			return kvbench.Result{}, err
+		}
+	} else {
+		elapsed, err = test.RunScenario(sc.Type, sc.ReadRatio)
+		if err != nil {
+			return kvbench.Result{}, err
+		}
+	}
+
+	if printResults {
+		test.PrintResults(name, elapsed)
+	}
+	return test.Result(name, elapsed), nil
+}
+
+// JSON 결과 파일을 읽습니다
+func readResults(path string) ([]kvbench.Result, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var results []kvbench.Result
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// 엔진과 시나리오가 같은 기준 결과보다 처리량이 maxRegression 비율 이상 떨어진 항목을 찾습니다
+// 기준 결과에 없는 시나리오는 비교하지 않습니다
+func compareBaseline(baseline, current []kvbench.Result, maxRegression float64) []string {
+	prev := make(map[string]kvbench.Result, len(baseline))
+	for _, r := range baseline {
+		prev[r.Engine+"/"+r.Scenario] = r
+	}
+
+	var regressions []string
+	for _, r := range current {
+		b, ok := prev[r.Engine+"/"+r.Scenario]
+		if !ok || b.OpsPerSec <= 0 {
+			continue
+		}
+		change := (r.OpsPerSec - b.OpsPerSec) / b.OpsPerSec
+		if change < -maxRegression {
+			regressions = append(regressions, fmt.Sprintf("성능 저하: %s/%s %.0f -> %.0f ops/sec (%.1f%%)",
+				r.Engine, r.Scenario, b.OpsPerSec, r.OpsPerSec, change*100))
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yiminan/go-examples/go-badger-db/kvbench"
+)
+
+// 테스트 함수 - YAML 시나리오가 플래그 기본값 위에 덮어써지는지 확인
+func TestLoadScenarios(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenarios.yaml")
+	data := []byte(`scenarios:
+  - name: 배치 쓰기
+    mode: batch
+    batchSize: 100
+  - type: mixed
+    readRatio: 0.8
+    duration: 30s
+    warmup: 5s
+`)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	base := scenario{Name: "플래그", Engine: kvbench.EnginePebble, Type: "write", Ops: 1000, KeySize: 16, ValueSize: 100, Mode: kvbench.WriteModeIndividual}
+	scenarios, err := loadScenarios(path, base)
+	if err != nil {
+		t.Fatalf("시나리오 로드 실패: %v", err)
+	}
+	if len(scenarios) != 2 {
+		t.Fatalf("시나리오 수 = %d, want 2", len(scenarios))
+	}
+
+	if sc := scenarios[0]; sc.Name != "배치 쓰기" || sc.Mode != kvbench.WriteModeBatch || sc.BatchSize != 100 || sc.Engine != kvbench.EnginePebble || sc.Ops != 1000 {
+		t.Errorf("첫 번째 시나리오 = %+v", sc)
+	}
+	sc := scenarios[1]
+	if sc.label() != "mixed/individual" || sc.ReadRatio != 0.8 || sc.Duration != 30*time.Second || sc.Warmup != 5*time.Second {
+		t.Errorf("두 번째 시나리오 = %+v (label %q)", sc, sc.label())
+	}
+
+	// 오타난 항목은 플래그 기본값으로 조용히 실행하지 않고 에러
+	for _, bad := range []string{"scenarios:\n  - mode: batch\n    batchsize: 100\n", "scenario:\n  - type: mixed\n"} {
+		if err := os.WriteFile(path, []byte(bad), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadScenarios(path, base); err == nil {
+			t.Errorf("%q: 에러 없음", bad)
+		}
+	}
+}
+
+// 테스트 함수 - 허용 범위를 넘는 처리량 감소만 성능 저하로 보고하는지 확인
+func TestCompareBaseline(t *testing.T) {
+	baseline := []kvbench.Result{
+		{Engine: "badger (디스크)", Scenario: "write", OpsPerSec: 1000},
+		{Engine: "badger (디스크)", Scenario: "read", OpsPerSec: 1000},
+	}
+	current := []kvbench.Result{
+		{Engine: "badger (디스크)", Scenario: "write", OpsPerSec: 950}, // -5%: 허용
+		{Engine: "badger (디스크)", Scenario: "read", OpsPerSec: 800},  // -20%: 저하
+		{Engine: "pebble (디스크)", Scenario: "read", OpsPerSec: 1},    // 기준 없음
+	}
+
+	regressions := compareBaseline(baseline, current, 0.1)
+	if len(regressions) != 1 {
+		t.Fatalf("성능 저하 = %v, want 1건", regressions)
+	}
+}
@@ -0,0 +1,339 @@
+// Package kvbench 는 Badger, Pebble 등 KV 엔진의 쓰기/읽기/혼합/YCSB 시나리오 성능을 측정합니다
+// (go test 테이블과 cmd/kvbench CLI 가 같은 구현을 사용합니다)
+package kvbench
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	badger "github.com/dgraph-io/badger/v4"
+	"github.com/dgraph-io/badger/v4/pb"
+	"github.com/dgraph-io/ristretto/z"
+)
+
+// 벤치마크 대상 엔진 종류
+const (
+	EngineBadger = "badger"
+	EnginePebble = "pebble"
+)
+
+// errKVNotFound 는 엔진과 관계없이 키가 없음을 나타냅니다
+var errKVNotFound = errors.New("key not found")
+
+// kvEngine 인터페이스는 벤치마크 대상 KV 스토어를 추상화합니다
+type kvEngine interface {
+	// Name 은 결과 표에 표시할 엔진 이름을 반환합니다 (예: "badger (디스크)")
+	Name() string
+	// Set 은 키 하나를 개별 트랜잭션(또는 개별 쓰기)으로 저장합니다
+	Set(key, value []byte) error
+	// Get 은 키의 값을 fn 에 전달합니다. 키가 없으면 errKVNotFound 를 반환합니다
+	Get(key []byte, fn func(val []byte) error) error
+	// SetBatch 는 여러 키를 하나의 배치로 저장합니다
+	SetBatch(keys, values [][]byte) error
+	// Scan 은 start 이상인 키를 정렬 순서대로 최대 limit 개 읽고, 읽은 개수를 반환합니다
+	Scan(start []byte, limit int, fn func(key, val []byte) error) (int, error)
+	// Close 는 DB를 닫고 임시 디렉토리를 정리합니다
+	Close() error
+}
+
+// kvBulkWriter 는 엔진 고유의 대량 쓰기 경로를 제공하는 선택적 인터페이스입니다
+// (현재 Badger 의 db.NewWriteBatch(), StreamWriter 만 해당)
+type kvBulkWriter interface {
+	// NewWriteBatch 는 내부적으로 트랜잭션을 나눠 비동기로 커밋하는 쓰기 배치를 만듭니다
+	NewWriteBatch() kvWriteBatch
+	// NewStreamWriter 는 정렬된 키를 빈 DB에 직접 기록하는 스트림 쓰기를 시작합니다
+	// (기존 데이터는 모두 삭제됩니다)
+	NewStreamWriter() (kvStreamWriter, error)
+}
+
+// kvWriteBatch 는 *badger.WriteBatch 와 같은 형태의 쓰기 배치입니다
+type kvWriteBatch interface {
+	Set(key, value []byte) error
+	Flush() error
+	Cancel()
+}
+
+// kvStreamWriter 는 정렬된 키 묶음을 순서대로 기록하는 스트림 쓰기입니다
+type kvStreamWriter interface {
+	Write(keys, values [][]byte) error
+	Flush() error
+	Cancel()
+}
+
+// 엔진 종류에 맞는 kvEngine 을 엽니다
+func openKVEngine(kind string, syncWrites, inMemory bool) (kvEngine, error) {
+	switch kind {
+	case EngineBadger:
+		return openBadgerEngine(syncWrites, inMemory)
+	case EnginePebble:
+		return openPebbleEngine(syncWrites, inMemory)
+	default:
+		return nil, fmt.Errorf("알 수 없는 엔진: %s", kind)
+	}
+}
+
+// 저장 모드를 사람이 읽을 수 있는 이름으로 변환합니다
+func storageLabel(inMemory bool) string {
+	if inMemory {
+		return "인메모리"
+	}
+	return "디스크"
+}
+
+// badgerEngine 은 Badger DB 기반 kvEngine 구현입니다
+type badgerEngine struct {
+	db       *badger.DB
+	tempDir  string
+	inMemory bool
+}
+
+// Badger DB를 벤치마크용 옵션으로 엽니다
+func openBadgerEngine(syncWrites, inMemory bool) (*badgerEngine, error) {
+	if inMemory {
+		// 인메모리 모드로 Badger DB 열기
+		options := badger.DefaultOptions("").WithInMemory(true)
+		options.Logger = nil // 로깅 비활성화
+
+		if syncWrites {
+			options = options.WithSyncWrites(true) // 동기 쓰기 옵션
+		}
+
+		db, err := badger.Open(options)
+		if err != nil {
+			return nil, fmt.Errorf("Badger DB 열기 실패: %w", err)
+		}
+		return &badgerEngine{db: db, inMemory: true}, nil
+	}
+
+	// 임시 디렉토리 생성
+	tempDir, err := os.MkdirTemp("", "badger-individual-write-test")
+	if err != nil {
+		return nil, fmt.Errorf("임시 디렉토리 생성 실패: %w", err)
+	}
+
+	// Badger DB 옵션 최적화
+	options := badger.DefaultOptions(tempDir)
+	options.Logger = nil // 로깅 비활성화
+
+	// 성능 최적화 옵션
+	options = options.WithNumVersionsToKeep(1)
+	options = options.WithNumLevelZeroTables(1)
+	options = options.WithNumLevelZeroTablesStall(2)
+	options = options.WithValueLogFileSize(256 << 20) // 256MB
+	options = options.WithMemTableSize(128 << 20)     // 128MB
+
+	if syncWrites {
+		options = options.WithSyncWrites(true)
+	}
+
+	// Badger DB 열기
+	db, err := badger.Open(options)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return nil, fmt.Errorf("Badger DB 열기 실패: %w", err)
+	}
+	return &badgerEngine{db: db, tempDir: tempDir}, nil
+}
+
+func (e *badgerEngine) Name() string {
+	return fmt.Sprintf("%s (%s)", EngineBadger, storageLabel(e.inMemory))
+}
+
+func (e *badgerEngine) Set(key, value []byte) error {
+	// 개별 쓰기 작업 수행 (각 쓰기마다 트랜잭션)
+	return e.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+func (e *badgerEngine) Get(key []byte, fn func(val []byte) error) error {
+	err := e.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		return item.Value(fn)
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return errKVNotFound
+	}
+	return err
+}
+
+func (e *badgerEngine) SetBatch(keys, values [][]byte) error {
+	return e.db.Update(func(txn *badger.Txn) error {
+		for i := range keys {
+			if err := txn.Set(keys[i], values[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (e *badgerEngine) Scan(start []byte, limit int, fn func(key, val []byte) error) (int, error) {
+	count := 0
+	err := e.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchSize = limit
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(start); it.Valid() && count < limit; it.Next() {
+			item := it.Item()
+			if err := item.Value(func(val []byte) error {
+				return fn(item.Key(), val)
+			}); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (e *badgerEngine) NewWriteBatch() kvWriteBatch {
+	return e.db.NewWriteBatch()
+}
+
+func (e *badgerEngine) NewStreamWriter() (kvStreamWriter, error) {
+	sw := e.db.NewStreamWriter()
+	if err := sw.Prepare(); err != nil {
+		return nil, fmt.Errorf("StreamWriter 준비 실패: %w", err)
+	}
+	return &badgerStreamWriter{sw: sw}, nil
+}
+
+// badgerStreamWriter 는 키 묶음을 z.Buffer 로 변환해 StreamWriter 에 전달합니다
+type badgerStreamWriter struct {
+	sw *badger.StreamWriter
+}
+
+func (w *badgerStreamWriter) Write(keys, values [][]byte) error {
+	buf := z.NewBuffer(1<<20, "kvbench.StreamWriter")
+	defer buf.Release()
+
+	for i := range keys {
+		// StreamWriter 는 버전(커밋 타임스탬프)이 0 이 아닌 KV 를 기대합니다
+		badger.KVToBuffer(&pb.KV{Key: keys[i], Value: values[i], Version: 1}, buf)
+	}
+	return w.sw.Write(buf)
+}
+
+func (w *badgerStreamWriter) Flush() error {
+	return w.sw.Flush()
+}
+
+func (w *badgerStreamWriter) Cancel() {
+	w.sw.Cancel()
+}
+
+func (e *badgerEngine) Close() error {
+	err := e.db.Close()
+	if e.tempDir != "" {
+		os.RemoveAll(e.tempDir)
+	}
+	return err
+}
+
+// pebbleEngine 은 Pebble DB 기반 kvEngine 구현입니다
+type pebbleEngine struct {
+	db        *pebble.DB
+	tempDir   string
+	inMemory  bool
+	writeOpts *pebble.WriteOptions
+}
+
+// Pebble DB를 엽니다. 인메모리 모드는 vfs.NewMem() 파일시스템을 사용합니다
+func openPebbleEngine(syncWrites, inMemory bool) (*pebbleEngine, error) {
+	// pebble.Sync 는 매 쓰기마다 WAL을 fsync 하고, pebble.NoSync 는 OS 버퍼에 맡깁니다
+	writeOpts := pebble.NoSync
+	if syncWrites {
+		writeOpts = pebble.Sync
+	}
+
+	if inMemory {
+		db, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+		if err != nil {
+			return nil, fmt.Errorf("Pebble DB 열기 실패: %w", err)
+		}
+		return &pebbleEngine{db: db, inMemory: true, writeOpts: writeOpts}, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "pebble-individual-write-test")
+	if err != nil {
+		return nil, fmt.Errorf("임시 디렉토리 생성 실패: %w", err)
+	}
+
+	// Badger 설정과 비슷하게 memtable 크기를 맞춤
+	options := &pebble.Options{
+		MemTableSize: 128 << 20, // 128MB
+	}
+	db, err := pebble.Open(tempDir, options)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return nil, fmt.Errorf("Pebble DB 열기 실패: %w", err)
+	}
+	return &pebbleEngine{db: db, tempDir: tempDir, writeOpts: writeOpts}, nil
+}
+
+func (e *pebbleEngine) Name() string {
+	return fmt.Sprintf("%s (%s)", EnginePebble, storageLabel(e.inMemory))
+}
+
+func (e *pebbleEngine) Set(key, value []byte) error {
+	return e.db.Set(key, value, e.writeOpts)
+}
+
+func (e *pebbleEngine) Get(key []byte, fn func(val []byte) error) error {
+	value, closer, err := e.db.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return errKVNotFound
+	}
+	if err != nil {
+		return err
+	}
+	// closer.Close() 이후에는 value가 무효화되므로 fn 안에서만 사용
+	defer closer.Close()
+	return fn(value)
+}
+
+func (e *pebbleEngine) SetBatch(keys, values [][]byte) error {
+	batch := e.db.NewBatch()
+	defer batch.Close()
+	for i := range keys {
+		if err := batch.Set(keys[i], values[i], nil); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(e.writeOpts)
+}
+
+func (e *pebbleEngine) Scan(start []byte, limit int, fn func(key, val []byte) error) (int, error) {
+	it, err := e.db.NewIter(nil)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	count := 0
+	for valid := it.SeekGE(start); valid && count < limit; valid = it.Next() {
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, it.Error()
+}
+
+func (e *pebbleEngine) Close() error {
+	err := e.db.Close()
+	if e.tempDir != "" {
+		os.RemoveAll(e.tempDir)
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+package kvbench
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// HDR 히스토그램과 같은 log-linear 버킷 구성
+// 2의 거듭제곱 구간마다 64개의 하위 버킷을 두므로 버킷 폭은 버킷 시작값의 1/64 이하이고,
+// 버킷 상한을 반환하는 Percentile 의 상대 오차는 최대 약 1.6% 입니다
+const (
+	histSubBucketBits  = 7
+	histSubBucketCount = 1 << histSubBucketBits // 128
+	histSubBucketHalf  = histSubBucketCount / 2 // 64
+	histBucketCount    = histSubBucketCount + (64-histSubBucketBits)*histSubBucketHalf
+)
+
+// latencyHistogram 은 나노초 단위 지연시간을 기록하는 HDR 스타일 히스토그램입니다
+// 워커마다 하나씩 사용하고(락 없음), 측정이 끝나면 Merge 로 합칩니다
+type latencyHistogram struct {
+	counts [histBucketCount]uint64
+	total  uint64
+	min    int64
+	max    int64
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{min: -1}
+}
+
+// 값이 속하는 버킷 인덱스를 계산합니다
+func histBucketIndex(v int64) int {
+	if v < histSubBucketCount {
+		return int(v)
+	}
+	exp := bits.Len64(uint64(v)) - histSubBucketBits
+	sub := int(v >> uint(exp))
+	return histSubBucketCount + (exp-1)*histSubBucketHalf + (sub - histSubBucketHalf)
+}
+
+// 버킷이 표현하는 가장 작은 값을 계산합니다
+func histBucketLowest(idx int) int64 {
+	if idx < histSubBucketCount {
+		return int64(idx)
+	}
+	idx -= histSubBucketCount
+	exp := idx/histSubBucketHalf + 1
+	sub := idx%histSubBucketHalf + histSubBucketHalf
+	return int64(sub) << uint(exp)
+}
+
+// 버킷이 표현하는 가장 큰 값을 계산합니다
+func histBucketHighest(idx int) int64 {
+	if idx+1 >= histBucketCount {
+		return 1<<63 - 1
+	}
+	return histBucketLowest(idx+1) - 1
+}
+
+// 지연시간 하나를 기록합니다
+func (h *latencyHistogram) Record(d time.Duration) {
+	v := int64(d)
+	if v < 0 {
+		v = 0
+	}
+	h.counts[histBucketIndex(v)]++
+	h.total++
+	if h.min < 0 || v < h.min {
+		h.min = v
+	}
+	if v > h.max {
+		h.max = v
+	}
+}
+
+// 다른 히스토그램의 기록을 합칩니다
+func (h *latencyHistogram) Merge(other *latencyHistogram) {
+	if other.total == 0 {
+		return
+	}
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+	h.total += other.total
+	if h.min < 0 || other.min < h.min {
+		h.min = other.min
+	}
+	if other.max > h.max {
+		h.max = other.max
+	}
+}
+
+// Count 는 기록된 값의 개수를 반환합니다
+func (h *latencyHistogram) Count() uint64 {
+	return h.total
+}
+
+// Max 는 기록된 최대 지연시간(정확한 값)을 반환합니다
+func (h *latencyHistogram) Max() time.Duration {
+	return time.Duration(h.max)
+}
+
+// Percentile 은 p(0~100) 백분위 지연시간을 반환합니다
+func (h *latencyHistogram) Percentile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(p / 100 * float64(h.total)))
+	if target == 0 {
+		target = 1
+	}
+	if target > h.total {
+		target = h.total
+	}
+
+	var seen uint64
+	for i, c := range h.counts {
+		seen += c
+		if seen >= target {
+			v := histBucketHighest(i)
+			// 버킷 경계값이 실제 최대값을 넘지 않도록 보정
+			if v > h.max {
+				v = h.max
+			}
+			return time.Duration(v)
+		}
+	}
+	return time.Duration(h.max)
+}
+
+// LatencySummary 는 결과 출력/저장용 백분위 요약입니다 (단위: µs)
+type LatencySummary struct {
+	Count uint64  `json:"count"`
+	P50   float64 `json:"p50Us"`
+	P90   float64 `json:"p90Us"`
+	P99   float64 `json:"p99Us"`
+	P999  float64 `json:"p999Us"`
+	Max   float64 `json:"maxUs"`
+}
+
+func toMicros(d time.Duration) float64 {
+	return float64(d.Nanoseconds()) / 1000
+}
+
+// Summary 는 p50/p90/p99/p99.9/max 요약을 반환합니다
+func (h *latencyHistogram) Summary() LatencySummary {
+	return LatencySummary{
+		Count: h.total,
+		P50:   toMicros(h.Percentile(50)),
+		P90:   toMicros(h.Percentile(90)),
+		P99:   toMicros(h.Percentile(99)),
+		P999:  toMicros(h.Percentile(99.9)),
+		Max:   toMicros(h.Max()),
+	}
+}
+
+func (s LatencySummary) String() string {
+	if s.Count == 0 {
+		return "기록 없음"
+	}
+	return fmt.Sprintf("p50=%.1f p90=%.1f p99=%.1f p99.9=%.1f max=%.1f (µs, %d건)",
+		s.P50, s.P90, s.P99, s.P999, s.Max, s.Count)
+}
@@ -0,0 +1,49 @@
+package kvbench
+
+import (
+	"testing"
+	"time"
+)
+
+// 테스트 함수 - 히스토그램 백분위가 1% 오차 이내인지 확인
+func TestLatencyHistogramPercentiles(t *testing.T) {
+	h := newLatencyHistogram()
+	// 1µs ~ 10000µs 를 균등하게 기록
+	for i := 1; i <= 10000; i++ {
+		h.Record(time.Duration(i) * time.Microsecond)
+	}
+
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{50, 5000 * time.Microsecond},
+		{90, 9000 * time.Microsecond},
+		{99, 9900 * time.Microsecond},
+		{99.9, 9990 * time.Microsecond},
+	}
+	for _, tt := range tests {
+		got := h.Percentile(tt.p)
+		diff := float64(got-tt.want) / float64(tt.want)
+		if diff < 0 || diff > 0.01 {
+			t.Errorf("p%v: expected ~%v, got %v", tt.p, tt.want, got)
+		}
+	}
+	if h.Max() != 10000*time.Microsecond {
+		t.Errorf("Expected max %v, got %v", 10000*time.Microsecond, h.Max())
+	}
+
+	// 워커별 히스토그램을 합친 결과가 하나로 기록한 결과와 같아야 함
+	a, b := newLatencyHistogram(), newLatencyHistogram()
+	for i := 1; i <= 10000; i++ {
+		if i%2 == 0 {
+			a.Record(time.Duration(i) * time.Microsecond)
+		} else {
+			b.Record(time.Duration(i) * time.Microsecond)
+		}
+	}
+	a.Merge(b)
+	if a.Count() != h.Count() || a.Percentile(99) != h.Percentile(99) || a.Max() != h.Max() {
+		t.Errorf("Merged histogram differs: count %d/%d, p99 %v/%v", a.Count(), h.Count(), a.Percentile(99), h.Percentile(99))
+	}
+}
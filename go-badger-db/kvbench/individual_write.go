@@ -0,0 +1,754 @@
+package kvbench
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 쓰기 테스트의 쓰기 방식
+const (
+	WriteModeIndividual = "individual" // 키마다 db.Update 트랜잭션
+	WriteModeBatch      = "batch"      // batchSize 개 키를 하나의 트랜잭션으로 커밋
+	WriteModeWriteBatch = "writebatch" // db.NewWriteBatch() (내부에서 트랜잭션을 나눠 비동기 커밋)
+	WriteModeStream     = "stream"     // StreamWriter (정렬된 키를 SST로 직접 기록, 단일 고루틴)
+)
+
+// ErrWriteModeUnsupported 는 엔진이 해당 쓰기 방식을 제공하지 않음을 나타냅니다
+var ErrWriteModeUnsupported = errors.New("지원하지 않는 쓰기 모드")
+
+// 시간 기반 실행에서 워커별 쓰기 키 범위의 최대 간격 (워커끼리 같은 키를 덮어쓰지 않도록)
+// 키 크기로 표현할 수 있는 인덱스가 적으면 키 공간을 워커 수로 나눈 간격을 사용합니다
+const durationKeyStride = 1_000_000_000
+
+// BadgerIndividualWriteTest 구조체는 개별 쓰기 작업에 대한 성능 테스트를 관리합니다
+// (Badger 외에 kvEngine 을 구현한 다른 엔진도 같은 시나리오로 측정할 수 있습니다)
+type BadgerIndividualWriteTest struct {
+	engine        kvEngine
+	numOperations int
+	numWorkers    int
+	keySize       int
+	valueSize     int
+	syncWrites    bool
+	inMemory      bool
+	writeMode     string
+	batchSize     int
+	stats         struct {
+		writeOps uint64
+		readOps  uint64
+		scanOps  uint64
+		errors   uint64
+	}
+
+	// 키 접근 분포와 값 크기 분포 (설정하지 않으면 기존처럼 순차 키, 고정 크기 값)
+	keyDist     string
+	keyChooser  keyChooser
+	valueSizer  valueSizer
+	recordCount int64 // 저장된 레코드 수 (insert 시 원자적으로 증가)
+
+	// 워커별 히스토그램을 합친 지연시간 (워커 종료 시 latencyMu 로 보호하며 병합)
+	latencyMu    sync.Mutex
+	readLatency  *latencyHistogram
+	writeLatency *latencyHistogram
+	scanLatency  *latencyHistogram
+	rmwLatency   *latencyHistogram
+
+	// 초당 처리량 시계열 (recordTimeSeries 가 true 일 때만 기록)
+	recordTimeSeries bool
+	throughput       []uint64
+
+	// 시간 기반 실행 (duration 이 0 이면 numOperations 만큼 실행)
+	duration time.Duration
+	warmup   time.Duration
+	deadline time.Time
+}
+
+// workerLatency 는 워커 하나가 락 없이 기록하는 작업 종류별 지연시간입니다
+type workerLatency struct {
+	read  *latencyHistogram
+	write *latencyHistogram
+	scan  *latencyHistogram
+	rmw   *latencyHistogram
+}
+
+func newWorkerLatency() *workerLatency {
+	return &workerLatency{
+		read:  newLatencyHistogram(),
+		write: newLatencyHistogram(),
+		scan:  newLatencyHistogram(),
+		rmw:   newLatencyHistogram(),
+	}
+}
+
+// 새로운 개별 쓰기 테스트 인스턴스를 생성합니다
+func NewBadgerIndividualWriteTest(numOps, workers, keySize, valueSize int, syncWrites, inMemory bool) (*BadgerIndividualWriteTest, error) {
+	return NewIndividualWriteTest(EngineBadger, numOps, workers, keySize, valueSize, syncWrites, inMemory)
+}
+
+// 지정한 엔진(badger, pebble)으로 개별 쓰기 테스트 인스턴스를 생성합니다
+func NewIndividualWriteTest(engineKind string, numOps, workers, keySize, valueSize int, syncWrites, inMemory bool) (*BadgerIndividualWriteTest, error) {
+	// 키는 인덱스를 keySize 자리 숫자로 쓰므로, 자리가 모자라면 서로 다른 인덱스가 같은 키가 됨
+	// (YCSB insert 는 미리 로드한 10% 뒤에 최대 numOps 개를 추가)
+	if numOps < 1 {
+		return nil, fmt.Errorf("작업 수는 1 이상이어야 합니다: %d", numOps)
+	}
+	// 워커마다 작업을 나눠 가지므로 작업 수보다 워커가 많으면 작업이 없는 워커가 생김
+	if workers < 1 || workers > numOps {
+		return nil, fmt.Errorf("워커 수 %d 는 1 이상, 작업 수 %d 이하여야 합니다", workers, numOps)
+	}
+	if maxKeys := numOps + preloadCount(numOps); keySpace(keySize) < maxKeys {
+		return nil, fmt.Errorf("키 크기 %d 바이트로는 키 %d 개를 구분할 수 없습니다 (최소 %d 바이트)", keySize, maxKeys, len(strconv.Itoa(maxKeys-1)))
+	}
+	if workers > keySpace(keySize) {
+		return nil, fmt.Errorf("키 크기 %d 바이트로는 워커 %d 개의 키 범위를 나눌 수 없습니다", keySize, workers)
+	}
+
+	engine, err := openKVEngine(engineKind, syncWrites, inMemory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BadgerIndividualWriteTest{
+		engine:        engine,
+		numOperations: numOps,
+		numWorkers:    workers,
+		keySize:       keySize,
+		valueSize:     valueSize,
+		syncWrites:    syncWrites,
+		inMemory:      inMemory,
+		writeMode:     WriteModeIndividual,
+		readLatency:   newLatencyHistogram(),
+		writeLatency:  newLatencyHistogram(),
+		scanLatency:   newLatencyHistogram(),
+		rmwLatency:    newLatencyHistogram(),
+	}, nil
+}
+
+// 쓰기 테스트의 쓰기 방식을 설정합니다
+// batchSize 는 batch 모드의 트랜잭션 크기, stream 모드의 버퍼 단위 키 수입니다
+func (t *BadgerIndividualWriteTest) SetWriteMode(mode string, batchSize int) error {
+	switch mode {
+	case WriteModeIndividual, WriteModeWriteBatch:
+	case WriteModeBatch, WriteModeStream:
+		if batchSize <= 0 {
+			return fmt.Errorf("%s 모드는 양수의 배치 크기가 필요합니다: %d", mode, batchSize)
+		}
+	default:
+		return fmt.Errorf("알 수 없는 쓰기 모드: %s", mode)
+	}
+
+	if mode == WriteModeWriteBatch || mode == WriteModeStream {
+		if _, ok := t.engine.(kvBulkWriter); !ok {
+			return fmt.Errorf("%s: %w: %s", t.engine.Name(), ErrWriteModeUnsupported, mode)
+		}
+	}
+
+	t.writeMode = mode
+	t.batchSize = batchSize
+	return nil
+}
+
+// 초당 처리량 시계열 기록 여부를 설정합니다
+func (t *BadgerIndividualWriteTest) SetRecordTimeSeries(enabled bool) {
+	t.recordTimeSeries = enabled
+}
+
+// 작업 수 대신 지정한 시간 동안 실행하도록 설정합니다 (0 이면 작업 수 기반)
+func (t *BadgerIndividualWriteTest) SetDuration(d time.Duration) {
+	t.duration = d
+}
+
+// 측정 전에 지정한 시간 동안 같은 시나리오를 실행해 캐시와 LSM 을 데웁니다
+// 워밍업 중의 작업은 통계와 지연시간에 포함하지 않습니다
+func (t *BadgerIndividualWriteTest) SetWarmup(d time.Duration) {
+	t.warmup = d
+}
+
+// 결과 출력용 쓰기 방식 이름
+func (t *BadgerIndividualWriteTest) writeModeLabel() string {
+	if t.writeMode == WriteModeBatch || t.writeMode == WriteModeStream {
+		return fmt.Sprintf("%s (N=%d)", t.writeMode, t.batchSize)
+	}
+	return t.writeMode
+}
+
+// 테스트 정리
+func (t *BadgerIndividualWriteTest) Cleanup() {
+	if t.engine != nil {
+		t.engine.Close()
+	}
+}
+
+// 키와 값 생성 함수
+func (t *BadgerIndividualWriteTest) generateKeyValue(idx int) ([]byte, []byte) {
+	// 값 크기 분포가 있으면 키마다 결정적으로 크기를 정함
+	valueSize := t.valueSize
+	if t.valueSizer != nil {
+		valueSize = t.valueSizer.Size(indexUniform(idx))
+	}
+	value := make([]byte, valueSize)
+
+	// 값 생성
+	valueStr := fmt.Sprintf("v-%0*d", valueSize-2, idx)
+	copy(value, valueStr)
+
+	return t.generateKey(idx), value
+}
+
+// keySize 자리 숫자 키로 구분할 수 있는 인덱스 수 (10^keySize, int 범위를 넘으면 math.MaxInt)
+func keySpace(keySize int) int {
+	n := 1
+	for i := 0; i < keySize; i++ {
+		if n > math.MaxInt/10 {
+			return math.MaxInt
+		}
+		n *= 10
+	}
+	return n
+}
+
+// 키 생성 함수 (고정 길이, 인덱스는 keySpace(keySize) 보다 작아야 서로 다른 키)
+func (t *BadgerIndividualWriteTest) generateKey(idx int) []byte {
+	key := make([]byte, t.keySize)
+	keyStr := fmt.Sprintf("%0*d", t.keySize, idx)
+	copy(key, keyStr)
+	return key
+}
+
+// [from, to) 범위의 키와 값을 생성합니다
+func (t *BadgerIndividualWriteTest) generateRange(from, to int) ([][]byte, [][]byte) {
+	keys := make([][]byte, 0, to-from)
+	values := make([][]byte, 0, to-from)
+	for i := from; i < to; i++ {
+		key, value := t.generateKeyValue(i)
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values
+}
+
+// 개별 쓰기 작업 수행
+func (t *BadgerIndividualWriteTest) writeOperation(idx int, lat *workerLatency) error {
+	key, value := t.generateKeyValue(idx)
+
+	// 개별 쓰기 작업 수행 (각 쓰기마다 트랜잭션)
+	start := time.Now()
+	err := t.engine.Set(key, value)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		atomic.AddUint64(&t.stats.writeOps, 1)
+		lat.write.Record(elapsed)
+	} else {
+		atomic.AddUint64(&t.stats.errors, 1)
+	}
+	return err
+}
+
+// 배치 쓰기 작업 수행 ([from, to) 범위를 하나의 트랜잭션으로 커밋)
+func (t *BadgerIndividualWriteTest) batchWriteOperation(from, to int, lat *workerLatency) error {
+	keys, values := t.generateRange(from, to)
+
+	start := time.Now()
+	err := t.engine.SetBatch(keys, values)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		atomic.AddUint64(&t.stats.writeOps, uint64(to-from))
+		// 배치 모드의 쓰기 지연시간은 커밋 단위로 기록
+		lat.write.Record(elapsed)
+	} else {
+		atomic.AddUint64(&t.stats.errors, 1)
+	}
+	return err
+}
+
+// 읽기 작업 수행
+func (t *BadgerIndividualWriteTest) readOperation(idx int, lat *workerLatency) error {
+	key := t.generateKey(idx)
+
+	// 값 읽기
+	start := time.Now()
+	err := t.engine.Get(key, func(val []byte) error {
+		// 값 사용 (실제로는 아무것도 하지 않음)
+		_ = val
+		return nil
+	})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		atomic.AddUint64(&t.stats.readOps, 1)
+		lat.read.Record(elapsed)
+	} else if err == errKVNotFound {
+		// KeyNotFound는 에러로 간주하지 않음 (아직 쓰여지지 않은 키일 수 있음)
+		// 없는 키 조회도 읽기 지연시간에는 포함
+		lat.read.Record(elapsed)
+	} else {
+		atomic.AddUint64(&t.stats.errors, 1)
+	}
+	return err
+}
+
+// 읽기/혼합/YCSB 테스트가 미리 로드할 레코드 수 (작업 수의 10%, 최소 1개)
+func preloadCount(numOps int) int {
+	return max(1, numOps/10)
+}
+
+// 데이터 미리 로드 (읽기 테스트를 위해)
+func (t *BadgerIndividualWriteTest) preloadData(count int) error {
+	fmt.Printf("데이터 미리 로드 중... (%d 항목)\n", count)
+
+	// 작은 배치로 나누어 데이터 로드
+	batchSize := 1000 // 한 트랜잭션에서 처리할 항목 수
+	for i := 0; i < count; i += batchSize {
+		end := i + batchSize
+		if end > count {
+			end = count
+		}
+
+		// 각 배치마다 새로운 트랜잭션 사용
+		keys, values := t.generateRange(i, end)
+
+		if err := t.engine.SetBatch(keys, values); err != nil {
+			return fmt.Errorf("배치 %d-%d 로드 실패: %w", i, end-1, err)
+		}
+
+		// 진행 상황 표시 (큰 데이터셋의 경우)
+		if count > 10000 && (i+batchSize)%(count/10) < batchSize {
+			fmt.Printf("  진행률: %.1f%% (%d/%d)\n", float64(i+batchSize)/float64(count)*100, i+batchSize, count)
+		}
+	}
+
+	atomic.StoreInt64(&t.recordCount, int64(count))
+	fmt.Printf("데이터 로드 완료: %d 항목\n", count)
+	return nil
+}
+
+// 워커가 처리할 키 인덱스 범위 [start, end)
+// 시간 기반 실행에서는 워커마다 durationKeyStride (키 공간이 작으면 키 공간/워커 수) 간격의 키 범위를
+// 종료 시각까지 사용하고, 범위를 다 쓰면 덮어쓰지 않도록 먼저 끝냅니다
+func (t *BadgerIndividualWriteTest) workerRange(workerID, opsPerWorker int) (int, int) {
+	if t.deadline.IsZero() {
+		start := workerID * opsPerWorker
+		return start, start + opsPerWorker
+	}
+	stride := min(durationKeyStride, keySpace(t.keySize)/t.numWorkers)
+	return workerID * stride, (workerID + 1) * stride
+}
+
+// 다음 작업을 계속할지 여부 (범위 끝까지, 시간 기반이면 종료 시각도 확인)
+func (t *BadgerIndividualWriteTest) keepRunning(idx, endIdx int) bool {
+	if t.deadline.IsZero() {
+		return idx < endIdx
+	}
+	return idx < endIdx && time.Now().Before(t.deadline)
+}
+
+// 작업 수 기반이면 end, 시간 기반이면 제한 없음 (같은 키를 반복해서 다루는 워커가 종료 시각까지 실행)
+func (t *BadgerIndividualWriteTest) untilDeadline(end int) int {
+	if t.deadline.IsZero() {
+		return end
+	}
+	return math.MaxInt
+}
+
+// 쓰기 전용 워커
+func (t *BadgerIndividualWriteTest) writeWorker(workerID int, wg *sync.WaitGroup, opsPerWorker int) {
+	defer wg.Done()
+
+	lat := newWorkerLatency()
+	defer t.mergeLatency(lat)
+
+	startIdx, endIdx := t.workerRange(workerID, opsPerWorker)
+
+	for i := startIdx; t.keepRunning(i, endIdx); i++ {
+		t.writeOperation(i, lat)
+	}
+}
+
+// 배치 트랜잭션 쓰기 워커 (batchSize 개 키마다 한 번 커밋)
+func (t *BadgerIndividualWriteTest) batchWriteWorker(workerID int, wg *sync.WaitGroup, opsPerWorker int) {
+	defer wg.Done()
+
+	lat := newWorkerLatency()
+	defer t.mergeLatency(lat)
+
+	startIdx, endIdx := t.workerRange(workerID, opsPerWorker)
+
+	for i := startIdx; t.keepRunning(i, endIdx); i += t.batchSize {
+		end := i + t.batchSize
+		if end > endIdx {
+			end = endIdx
+		}
+		t.batchWriteOperation(i, end, lat)
+	}
+}
+
+// WriteBatch 쓰기 워커 (워커마다 하나의 WriteBatch 에 모든 키를 넣고 마지막에 Flush)
+func (t *BadgerIndividualWriteTest) writeBatchWorker(workerID int, wg *sync.WaitGroup, opsPerWorker int) {
+	defer wg.Done()
+
+	lat := newWorkerLatency()
+	defer t.mergeLatency(lat)
+
+	startIdx, endIdx := t.workerRange(workerID, opsPerWorker)
+
+	wb := t.engine.(kvBulkWriter).NewWriteBatch()
+	for i := startIdx; t.keepRunning(i, endIdx); i++ {
+		key, value := t.generateKeyValue(i)
+
+		// Set 은 내부 트랜잭션이 가득 차면 커밋을 기다리므로 지연시간을 키 단위로 기록
+		start := time.Now()
+		err := wb.Set(key, value)
+		elapsed := time.Since(start)
+
+		if err != nil {
+			atomic.AddUint64(&t.stats.errors, 1)
+			wb.Cancel()
+			return
+		}
+		atomic.AddUint64(&t.stats.writeOps, 1)
+		lat.write.Record(elapsed)
+	}
+
+	if err := wb.Flush(); err != nil {
+		atomic.AddUint64(&t.stats.errors, 1)
+	}
+}
+
+// StreamWriter 쓰기 (전체 키를 batchSize 단위 버퍼로 나누어 정렬된 순서로 기록)
+// StreamWriter 는 내부적으로 병렬 처리하므로 단일 고루틴에서 실행합니다
+func (t *BadgerIndividualWriteTest) streamWrite() {
+	lat := newWorkerLatency()
+	defer t.mergeLatency(lat)
+
+	sw, err := t.engine.(kvBulkWriter).NewStreamWriter()
+	if err != nil {
+		atomic.AddUint64(&t.stats.errors, 1)
+		return
+	}
+
+	// 키는 0으로 채운 고정 길이 숫자이므로 인덱스 순서가 곧 정렬 순서
+	for i := 0; i < t.numOperations; i += t.batchSize {
+		end := i + t.batchSize
+		if end > t.numOperations {
+			end = t.numOperations
+		}
+		keys, values := t.generateRange(i, end)
+
+		start := time.Now()
+		err := sw.Write(keys, values)
+		elapsed := time.Since(start)
+
+		if err != nil {
+			atomic.AddUint64(&t.stats.errors, 1)
+			sw.Cancel()
+			return
+		}
+		atomic.AddUint64(&t.stats.writeOps, uint64(end-i))
+		lat.write.Record(elapsed)
+	}
+
+	if err := sw.Flush(); err != nil {
+		atomic.AddUint64(&t.stats.errors, 1)
+	}
+}
+
+// 읽기 전용 워커
+func (t *BadgerIndividualWriteTest) readWorker(workerID int, wg *sync.WaitGroup, opsPerWorker int) {
+	defer wg.Done()
+
+	lat := newWorkerLatency()
+	defer t.mergeLatency(lat)
+
+	// 시간 기반 실행에서도 읽기는 작업 수 기반과 같은 키 범위를 반복해서 읽음
+	startIdx := workerID * opsPerWorker
+	endIdx := startIdx + opsPerWorker
+
+	for i := startIdx; t.keepRunning(i, t.untilDeadline(endIdx)); i++ {
+		t.readOperation(startIdx+(i-startIdx)%opsPerWorker, lat)
+	}
+}
+
+// 읽기/쓰기 혼합 워커
+func (t *BadgerIndividualWriteTest) mixedWorker(workerID int, wg *sync.WaitGroup, opsPerWorker int, readRatio float64) {
+	defer wg.Done()
+
+	lat := newWorkerLatency()
+	defer t.mergeLatency(lat)
+
+	startIdx, endIdx := t.workerRange(workerID, opsPerWorker)
+
+	// 난수 생성기 초기화 (각 워커마다 다른 시드)
+	r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
+
+	for i := startIdx; t.keepRunning(i, endIdx); i++ {
+		// 읽기 비율에 따라 읽기 또는 쓰기 결정
+		if r.Float64() < readRatio {
+			// 읽기 작업 수행
+			if t.keyChooser != nil {
+				// 키 분포에 따라 미리 로드한 키 중에서 선택 (핫키 접근 재현)
+				t.readOperation(t.keyChooser.Next(r, int(atomic.LoadInt64(&t.recordCount))), lat)
+			} else {
+				t.readOperation(i%(startIdx+1), lat) // 이미 쓰여진 키만 읽기 위해 인덱스 조정
+			}
+		} else {
+			// 쓰기 작업 수행
+			t.writeOperation(i, lat)
+		}
+	}
+}
+
+// 워커의 지연시간 히스토그램을 전체 결과에 병합합니다
+func (t *BadgerIndividualWriteTest) mergeLatency(lat *workerLatency) {
+	t.latencyMu.Lock()
+	defer t.latencyMu.Unlock()
+	t.readLatency.Merge(lat.read)
+	t.writeLatency.Merge(lat.write)
+	t.scanLatency.Merge(lat.scan)
+	t.rmwLatency.Merge(lat.rmw)
+}
+
+// 초당 처리량(읽기+쓰기) 샘플링을 시작하고, 샘플링을 멈추는 함수를 반환합니다
+// 컴팩션 등으로 인한 일시적인 처리량 급감(stall)을 확인하는 용도입니다
+func (t *BadgerIndividualWriteTest) startThroughputSampler() func() {
+	if !t.recordTimeSeries {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		last := t.completedOps()
+		for {
+			select {
+			case <-ticker.C:
+				current := t.completedOps()
+				t.throughput = append(t.throughput, current-last)
+				last = current
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// 측정 시작 시각을 기록하고, 시간 기반 실행이면 종료 시각을 정합니다
+func (t *BadgerIndividualWriteTest) start() time.Time {
+	now := time.Now()
+	t.deadline = time.Time{}
+	if t.duration > 0 {
+		t.deadline = now.Add(t.duration)
+	}
+	return now
+}
+
+// 통계, 지연시간, 시계열을 초기화합니다 (워밍업 결과를 버릴 때 사용)
+func (t *BadgerIndividualWriteTest) ResetStats() {
+	atomic.StoreUint64(&t.stats.writeOps, 0)
+	atomic.StoreUint64(&t.stats.readOps, 0)
+	atomic.StoreUint64(&t.stats.scanOps, 0)
+	atomic.StoreUint64(&t.stats.errors, 0)
+	t.readLatency = newLatencyHistogram()
+	t.writeLatency = newLatencyHistogram()
+	t.scanLatency = newLatencyHistogram()
+	t.rmwLatency = newLatencyHistogram()
+	t.throughput = nil
+}
+
+// 지금까지 완료된 읽기/쓰기 작업 수
+func (t *BadgerIndividualWriteTest) completedOps() uint64 {
+	return atomic.LoadUint64(&t.stats.readOps) + atomic.LoadUint64(&t.stats.writeOps) + atomic.LoadUint64(&t.stats.scanOps)
+}
+
+// 쓰기 전용 테스트 실행
+func (t *BadgerIndividualWriteTest) RunWriteTest() time.Duration {
+	var wg sync.WaitGroup
+	opsPerWorker := t.numOperations / t.numWorkers
+
+	// 시작 시간 기록
+	startTime := t.start()
+	stopSampler := t.startThroughputSampler()
+
+	// 쓰기 방식에 따라 워커 고루틴 시작
+	switch t.writeMode {
+	case WriteModeStream:
+		t.streamWrite()
+	default:
+		worker := t.writeWorker
+		switch t.writeMode {
+		case WriteModeBatch:
+			worker = t.batchWriteWorker
+		case WriteModeWriteBatch:
+			worker = t.writeBatchWorker
+		}
+		for w := 0; w < t.numWorkers; w++ {
+			wg.Add(1)
+			go worker(w, &wg, opsPerWorker)
+		}
+	}
+
+	// 모든 워커가 완료될 때까지 대기
+	wg.Wait()
+	stopSampler()
+
+	// 경과 시간 계산
+	return time.Since(startTime)
+}
+
+// 읽기 전용 테스트 실행
+func (t *BadgerIndividualWriteTest) RunReadTest() time.Duration {
+	var wg sync.WaitGroup
+	opsPerWorker := t.numOperations / t.numWorkers
+
+	// 시작 시간 기록
+	startTime := t.start()
+	stopSampler := t.startThroughputSampler()
+
+	// 워커 고루틴 시작
+	for w := 0; w < t.numWorkers; w++ {
+		wg.Add(1)
+		go t.readWorker(w, &wg, opsPerWorker)
+	}
+
+	// 모든 워커가 완료될 때까지 대기
+	wg.Wait()
+	stopSampler()
+
+	// 경과 시간 계산
+	return time.Since(startTime)
+}
+
+// 읽기/쓰기 혼합 테스트 실행
+func (t *BadgerIndividualWriteTest) RunMixedTest(readRatio float64) time.Duration {
+	var wg sync.WaitGroup
+	opsPerWorker := t.numOperations / t.numWorkers
+
+	// 시작 시간 기록
+	startTime := t.start()
+	stopSampler := t.startThroughputSampler()
+
+	// 워커 고루틴 시작
+	for w := 0; w < t.numWorkers; w++ {
+		wg.Add(1)
+		go t.mixedWorker(w, &wg, opsPerWorker, readRatio)
+	}
+
+	// 모든 워커가 완료될 때까지 대기
+	wg.Wait()
+	stopSampler()
+
+	// 경과 시간 계산
+	return time.Since(startTime)
+}
+
+// 테스트 유형(write, read, mixed)에 따라 시나리오를 실행합니다
+// 읽기/혼합 테스트는 전체 작업 수의 10%를 미리 로드한 뒤 측정합니다
+// (쓰기 방식은 write 테스트에만 적용되고, 혼합 테스트의 쓰기는 항상 개별 트랜잭션입니다)
+func (t *BadgerIndividualWriteTest) RunScenario(testType string, readRatio float64) (time.Duration, error) {
+	var run func() time.Duration
+	switch testType {
+	case "write":
+		// StreamWriter 는 빈 DB 에 한 번만 기록할 수 있으므로 시간 기반 실행과 워밍업을 지원하지 않음
+		if t.writeMode == WriteModeStream && (t.duration > 0 || t.warmup > 0) {
+			return 0, fmt.Errorf("%s 모드는 시간 기반 실행과 워밍업을 지원하지 않습니다", t.writeMode)
+		}
+		run = t.RunWriteTest
+	case "read":
+		// 읽기 테스트를 위해 데이터 미리 로드
+		if err := t.preloadData(preloadCount(t.numOperations)); err != nil {
+			return 0, err
+		}
+		run = t.RunReadTest
+	case "mixed":
+		// 혼합 테스트를 위해 일부 데이터 미리 로드
+		if err := t.preloadData(preloadCount(t.numOperations)); err != nil {
+			return 0, err
+		}
+		run = func() time.Duration { return t.RunMixedTest(readRatio) }
+	default:
+		return 0, fmt.Errorf("알 수 없는 테스트 유형: %s", testType)
+	}
+
+	t.runWarmup(func() { run() })
+	return run(), nil
+}
+
+// 워밍업이 설정되어 있으면 run 을 워밍업 시간 동안 실행한 뒤 통계를 초기화합니다
+func (t *BadgerIndividualWriteTest) runWarmup(run func()) {
+	if t.warmup <= 0 {
+		return
+	}
+	fmt.Printf("워밍업 중... (%v)\n", t.warmup)
+
+	duration, recordTimeSeries := t.duration, t.recordTimeSeries
+	t.duration, t.recordTimeSeries = t.warmup, false
+	run()
+	t.duration, t.recordTimeSeries = duration, recordTimeSeries
+
+	t.ResetStats()
+}
+
+// 키당 평균 쓰기 비용 (쓰기 전용 테스트의 경과 시간 / 쓰기 수, 혼합 테스트에서는 0)
+func (t *BadgerIndividualWriteTest) amortizedWriteCost(elapsed time.Duration) time.Duration {
+	if t.stats.writeOps == 0 || t.stats.readOps > 0 || t.stats.scanOps > 0 {
+		return 0
+	}
+	return elapsed / time.Duration(t.stats.writeOps)
+}
+
+// 결과 출력
+func (t *BadgerIndividualWriteTest) PrintResults(testName string, elapsed time.Duration) {
+	totalOps := t.stats.readOps + t.stats.writeOps + t.stats.scanOps
+	opsPerSec := float64(totalOps) / elapsed.Seconds()
+
+	fmt.Printf("\n===== %s 개별 쓰기 테스트 결과: %s =====\n", t.engine.Name(), testName)
+	fmt.Printf("총 작업 수: %d (읽기: %d, 쓰기: %d, 스캔: %d)\n", totalOps, t.stats.readOps, t.stats.writeOps, t.stats.scanOps)
+	fmt.Printf("고루틴 수: %d\n", t.numWorkers)
+	fmt.Printf("키 크기: %d bytes, 값 크기: %d bytes\n", t.keySize, t.valueSize)
+	fmt.Printf("동기 쓰기: %v\n", t.syncWrites)
+	fmt.Printf("인메모리 모드: %v\n", t.inMemory)
+	fmt.Printf("쓰기 모드: %s\n", t.writeModeLabel())
+	if t.keyDist != "" {
+		fmt.Printf("키 분포: %s\n", t.keyDist)
+	}
+	fmt.Printf("소요 시간: %v\n", elapsed)
+	fmt.Printf("초당 작업 수: %.2f ops/sec\n", opsPerSec)
+	fmt.Printf("에러 수: %d\n", t.stats.errors)
+	if cost := t.amortizedWriteCost(elapsed); cost > 0 {
+		fmt.Printf("키당 평균 쓰기 비용: %.3f µs (amortized)\n", toMicros(cost))
+	}
+	fmt.Printf("읽기 지연시간: %s\n", t.readLatency.Summary())
+	if t.writeMode == WriteModeBatch || t.writeMode == WriteModeStream {
+		fmt.Printf("쓰기 지연시간 (커밋 단위): %s\n", t.writeLatency.Summary())
+	} else {
+		fmt.Printf("쓰기 지연시간: %s\n", t.writeLatency.Summary())
+	}
+	if t.scanLatency.Count() > 0 {
+		fmt.Printf("스캔 지연시간: %s\n", t.scanLatency.Summary())
+	}
+	if t.rmwLatency.Count() > 0 {
+		fmt.Printf("read-modify-write 지연시간: %s\n", t.rmwLatency.Summary())
+	}
+	if len(t.throughput) > 0 {
+		fmt.Printf("초당 처리량 (ops/sec):\n")
+		for sec, ops := range t.throughput {
+			fmt.Printf("  %3ds: %d\n", sec+1, ops)
+		}
+	}
+	fmt.Printf("=====================================\n")
+}
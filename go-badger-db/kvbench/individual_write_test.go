@@ -0,0 +1,164 @@
+package kvbench
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// 테스트 함수 - 다양한 설정으로 개별 쓰기 성능 테스트 실행
+func TestBadgerIndividualWrites(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	// CPU 코어 수 확인
+	cpuCores := runtime.NumCPU()
+	fmt.Printf("CPU 코어 수: %d\n", cpuCores)
+
+	// 테스트 설정
+	testConfigs := []struct {
+		name       string
+		ops        int
+		workers    int
+		keySize    int
+		valueSize  int
+		syncWrites bool
+		inMemory   bool
+		testType   string
+		readRatio  float64
+		writeMode  string
+		batchSize  int
+	}{
+		{"비동기 개별 쓰기 (디스크)", 1000000, cpuCores, 16, 100, false, false, "write", 0.0, WriteModeIndividual, 0},
+		{"동기 개별 쓰기 (디스크)", 100000, cpuCores, 16, 100, true, false, "write", 0.0, WriteModeIndividual, 0},
+		{"비동기 개별 쓰기 (인메모리)", 1000000, cpuCores, 16, 100, false, true, "write", 0.0, WriteModeIndividual, 0},
+		{"읽기 전용 (디스크)", 1000000, cpuCores, 16, 100, false, false, "read", 1.0, WriteModeIndividual, 0},
+		{"읽기 전용 (인메모리)", 1000000, cpuCores, 16, 100, false, true, "read", 1.0, WriteModeIndividual, 0},
+		{"읽기/쓰기 혼합 50:50 (디스크)", 1000000, cpuCores, 16, 100, false, false, "mixed", 0.5, WriteModeIndividual, 0},
+		{"읽기/쓰기 혼합 80:20 (디스크)", 1000000, cpuCores, 16, 100, false, false, "mixed", 0.8, WriteModeIndividual, 0},
+		{"고루틴 확장 쓰기 (디스크)", 1000000, cpuCores * 4, 16, 100, false, false, "write", 0.0, WriteModeIndividual, 0},
+		{"배치 트랜잭션 쓰기 N=100 (디스크)", 1000000, cpuCores, 16, 100, false, false, "write", 0.0, WriteModeBatch, 100},
+		{"배치 트랜잭션 쓰기 N=1000 (디스크)", 1000000, cpuCores, 16, 100, false, false, "write", 0.0, WriteModeBatch, 1000},
+		{"WriteBatch 쓰기 (디스크)", 1000000, cpuCores, 16, 100, false, false, "write", 0.0, WriteModeWriteBatch, 0},
+		{"StreamWriter 쓰기 (디스크)", 1000000, 1, 16, 100, false, false, "write", 0.0, WriteModeStream, 10000},
+	}
+
+	// 각 설정으로 테스트 실행
+	for _, cfg := range testConfigs {
+		t.Run(cfg.name, func(t *testing.T) {
+			fmt.Printf("\n=== 테스트 시작: %s ===\n", cfg.name)
+
+			// 테스트 인스턴스 생성
+			test, err := NewBadgerIndividualWriteTest(
+				cfg.ops, cfg.workers, cfg.keySize, cfg.valueSize, cfg.syncWrites, cfg.inMemory,
+			)
+			if err != nil {
+				t.Fatalf("테스트 초기화 실패: %v", err)
+			}
+			defer test.Cleanup()
+			test.SetRecordTimeSeries(*kvBenchTimeSeries)
+			if err := test.SetWriteMode(cfg.writeMode, cfg.batchSize); err != nil {
+				t.Fatalf("쓰기 모드 설정 실패: %v", err)
+			}
+
+			// 테스트 유형에 따라 실행
+			elapsed, err := test.RunScenario(cfg.testType, cfg.readRatio)
+			if err != nil {
+				t.Fatalf("테스트 실행 실패: %v", err)
+			}
+
+			// 결과 출력
+			test.PrintResults(cfg.name, elapsed)
+		})
+	}
+}
+
+// 테스트 함수 - 시간 기반 실행이 종료 시각까지 실행되고 워밍업 작업은 통계에서 제외되는지 확인
+func TestDurationRunWithWarmup(t *testing.T) {
+	test, err := NewIndividualWriteTest(EngineBadger, 1000, 2, 16, 100, false, true)
+	if err != nil {
+		t.Fatalf("테스트 초기화 실패: %v", err)
+	}
+	defer test.Cleanup()
+	test.SetDuration(200 * time.Millisecond)
+	test.SetWarmup(100 * time.Millisecond)
+
+	elapsed, err := test.RunScenario("write", 0.0)
+	if err != nil {
+		t.Fatalf("테스트 실행 실패: %v", err)
+	}
+	if elapsed < 200*time.Millisecond {
+		t.Errorf("경과 시간 = %v, want >= 200ms", elapsed)
+	}
+
+	r := test.Result("write", elapsed)
+	if r.WriteOps == 0 || r.Errors != 0 {
+		t.Errorf("쓰기 수 = %d, 에러 수 = %d", r.WriteOps, r.Errors)
+	}
+	if r.WriteLatency.Count != r.WriteOps {
+		t.Errorf("쓰기 지연시간 기록 수 = %d, want %d (워밍업 작업이 섞이면 안 됨)", r.WriteLatency.Count, r.WriteOps)
+	}
+}
+
+// 테스트 함수 - 키 크기가 작아도 시간 기반 실행의 워커들이 서로 다른 키만 쓰고, 키가 모자라면 생성을 거부하는지 확인
+func TestKeySpaceLimits(t *testing.T) {
+	if _, err := NewIndividualWriteTest(EngineBadger, 1000, 2, 3, 100, false, true); err == nil {
+		t.Fatal("키 1100 개를 3 바이트 키로 만들었는데 에러가 없음")
+	}
+
+	// 3 바이트 키 공간(1000)을 워커 4 개가 250 개씩 나눠 씀
+	test, err := NewIndividualWriteTest(EngineBadger, 100, 4, 3, 100, false, true)
+	if err != nil {
+		t.Fatalf("테스트 초기화 실패: %v", err)
+	}
+	defer test.Cleanup()
+	test.SetDuration(2 * time.Second)
+
+	elapsed, err := test.RunScenario("write", 0.0)
+	if err != nil {
+		t.Fatalf("테스트 실행 실패: %v", err)
+	}
+	r := test.Result("write", elapsed)
+	keys, err := test.engine.Scan(nil, 2000, func(key, val []byte) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.WriteOps != 1000 || keys != 1000 {
+		t.Errorf("쓰기 수 = %d, 키 수 = %d, want 1000 (키 범위를 다 쓰면 덮어쓰지 않고 끝나야 함)", r.WriteOps, keys)
+	}
+}
+
+// 테스트 함수 - 작업 수가 10 보다 작아도 미리 로드할 레코드가 있어 읽기/YCSB 실행이 가능하고, 작업 수보다 많은 워커는 거부하는지 확인
+func TestSmallOperationCounts(t *testing.T) {
+	if _, err := NewIndividualWriteTest(EngineBadger, 5, 8, 16, 100, false, true); err == nil {
+		t.Fatal("작업 5 개에 워커 8 개를 만들었는데 에러가 없음")
+	}
+
+	for _, dist := range []string{"", KeyDistUniform, KeyDistZipfian} {
+		test, err := NewIndividualWriteTest(EngineBadger, 5, 1, 16, 100, false, true)
+		if err != nil {
+			t.Fatalf("테스트 초기화 실패: %v", err)
+		}
+		if dist != "" {
+			if err := test.SetKeyDistribution(dist, 0); err != nil {
+				t.Fatalf("키 분포 설정 실패: %v", err)
+			}
+		}
+		if _, err := test.RunWorkload(YCSBWorkloads[2]); err != nil {
+			t.Errorf("YCSB-C (%q): %v", dist, err)
+		}
+		test.Cleanup()
+	}
+
+	test, err := NewIndividualWriteTest(EngineBadger, 5, 5, 16, 100, false, true)
+	if err != nil {
+		t.Fatalf("테스트 초기화 실패: %v", err)
+	}
+	defer test.Cleanup()
+	test.SetDuration(200 * time.Millisecond)
+	if _, err := test.RunScenario("read", 1.0); err != nil {
+		t.Fatalf("테스트 실행 실패: %v", err)
+	}
+}
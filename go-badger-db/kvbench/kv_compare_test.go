@@ -0,0 +1,88 @@
+package kvbench
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+var (
+	kvBenchOps = flag.Int("kvbench.ops", 100000, "엔진 비교 테스트의 시나리오별 작업 수")
+	kvBenchOut = flag.String("kvbench.out", "kvbench_results", "엔진 비교 결과 파일 경로 (확장자 제외, .json/.csv 생성, 빈 값이면 저장 안 함)")
+
+	kvBenchTimeSeries = flag.Bool("kvbench.timeseries", false, "초당 처리량 시계열 기록 여부 (컴팩션 stall 확인용)")
+)
+
+// 테스트 함수 - 같은 쓰기/읽기/혼합 시나리오를 Badger, Pebble 엔진에서 실행하고 비교
+func TestKVEngineComparison(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	cpuCores := runtime.NumCPU()
+	ops := *kvBenchOps
+
+	// 비교 대상 엔진 (디스크, 인메모리)
+	engines := []struct {
+		kind     string
+		inMemory bool
+	}{
+		{EngineBadger, false},
+		{EngineBadger, true},
+		{EnginePebble, false},
+		{EnginePebble, true},
+	}
+
+	// 모든 엔진에서 동일하게 실행할 시나리오
+	scenarios := []struct {
+		name      string
+		testType  string
+		readRatio float64
+		writeMode string
+		batchSize int
+	}{
+		{"비동기 개별 쓰기", "write", 0.0, WriteModeIndividual, 0},
+		{"배치 트랜잭션 쓰기 N=100", "write", 0.0, WriteModeBatch, 100},
+		{"읽기 전용", "read", 1.0, WriteModeIndividual, 0},
+		{"읽기/쓰기 혼합 50:50", "mixed", 0.5, WriteModeIndividual, 0},
+		{"읽기/쓰기 혼합 80:20", "mixed", 0.8, WriteModeIndividual, 0},
+	}
+
+	var results []Result
+	for _, sc := range scenarios {
+		for _, eng := range engines {
+			name := fmt.Sprintf("%s/%s/%s", sc.name, eng.kind, storageLabel(eng.inMemory))
+			t.Run(name, func(t *testing.T) {
+				test, err := NewIndividualWriteTest(eng.kind, ops, cpuCores, 16, 100, false, eng.inMemory)
+				if err != nil {
+					t.Fatalf("테스트 초기화 실패: %v", err)
+				}
+				defer test.Cleanup()
+				test.SetRecordTimeSeries(*kvBenchTimeSeries)
+				if err := test.SetWriteMode(sc.writeMode, sc.batchSize); err != nil {
+					t.Fatalf("쓰기 모드 설정 실패: %v", err)
+				}
+
+				elapsed, err := test.RunScenario(sc.testType, sc.readRatio)
+				if err != nil {
+					t.Fatalf("테스트 실행 실패: %v", err)
+				}
+				results = append(results, test.Result(sc.name, elapsed))
+			})
+		}
+	}
+
+	PrintComparisonTable(results)
+
+	if *kvBenchOut == "" {
+		return
+	}
+	if err := WriteResultsJSON(*kvBenchOut+".json", results); err != nil {
+		t.Fatalf("JSON 결과 저장 실패: %v", err)
+	}
+	if err := WriteResultsCSV(*kvBenchOut+".csv", results); err != nil {
+		t.Fatalf("CSV 결과 저장 실패: %v", err)
+	}
+	fmt.Printf("결과 저장: %s.json, %s.csv\n", *kvBenchOut, *kvBenchOut)
+}
@@ -0,0 +1,184 @@
+package kvbench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Result 는 엔진/시나리오 하나의 측정 결과입니다
+type Result struct {
+	Engine     string  `json:"engine"`
+	Scenario   string  `json:"scenario"`
+	Workers    int     `json:"workers"`
+	KeySize    int     `json:"keySize"`
+	ValueSize  int     `json:"valueSize"`
+	SyncWrites bool    `json:"syncWrites"`
+	WriteMode  string  `json:"writeMode"`
+	KeyDist    string  `json:"keyDist,omitempty"`
+	BatchSize  int     `json:"batchSize,omitempty"`
+	TotalOps   uint64  `json:"totalOps"`
+	ReadOps    uint64  `json:"readOps"`
+	WriteOps   uint64  `json:"writeOps"`
+	ScanOps    uint64  `json:"scanOps"`
+	Errors     uint64  `json:"errors"`
+	ElapsedMs  float64 `json:"elapsedMs"`
+	OpsPerSec  float64 `json:"opsPerSec"`
+
+	// 쓰기 전용 테스트의 키당 평균 쓰기 비용 (µs)
+	WriteCostPerKeyUs float64 `json:"writeCostPerKeyUs,omitempty"`
+
+	ReadLatency  LatencySummary `json:"readLatency"`
+	WriteLatency LatencySummary `json:"writeLatency"`
+	ScanLatency  LatencySummary `json:"scanLatency"`
+	RMWLatency   LatencySummary `json:"rmwLatency"`
+	Throughput   []uint64       `json:"throughputPerSec,omitempty"`
+}
+
+// 측정이 끝난 테스트의 결과를 Result 로 변환합니다
+func (t *BadgerIndividualWriteTest) Result(scenario string, elapsed time.Duration) Result {
+	totalOps := t.stats.readOps + t.stats.writeOps + t.stats.scanOps
+	return Result{
+		Engine:     t.engine.Name(),
+		Scenario:   scenario,
+		Workers:    t.numWorkers,
+		KeySize:    t.keySize,
+		ValueSize:  t.valueSize,
+		SyncWrites: t.syncWrites,
+		WriteMode:  t.writeMode,
+		KeyDist:    t.keyDist,
+		BatchSize:  t.batchSize,
+		TotalOps:   totalOps,
+		ReadOps:    t.stats.readOps,
+		WriteOps:   t.stats.writeOps,
+		ScanOps:    t.stats.scanOps,
+		Errors:     t.stats.errors,
+		ElapsedMs:  float64(elapsed.Microseconds()) / 1000,
+		OpsPerSec:  float64(totalOps) / elapsed.Seconds(),
+
+		WriteCostPerKeyUs: toMicros(t.amortizedWriteCost(elapsed)),
+
+		ReadLatency:  t.readLatency.Summary(),
+		WriteLatency: t.writeLatency.Summary(),
+		ScanLatency:  t.scanLatency.Summary(),
+		RMWLatency:   t.rmwLatency.Summary(),
+		Throughput:   t.throughput,
+	}
+}
+
+// 시나리오를 행, 엔진을 열로 하는 ops/sec 비교 표를 출력합니다
+func PrintComparisonTable(results []Result) {
+	var engines, scenarios []string
+	cells := make(map[string]map[string]float64)
+	for _, r := range results {
+		if _, ok := cells[r.Scenario]; !ok {
+			scenarios = append(scenarios, r.Scenario)
+			cells[r.Scenario] = make(map[string]float64)
+		}
+		if !contains(engines, r.Engine) {
+			engines = append(engines, r.Engine)
+		}
+		cells[r.Scenario][r.Engine] = r.OpsPerSec
+	}
+
+	fmt.Printf("\n===== 엔진 비교 결과 (ops/sec) =====\n")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "시나리오\t")
+	for _, e := range engines {
+		fmt.Fprintf(w, "%s\t", e)
+	}
+	fmt.Fprintln(w)
+	for _, s := range scenarios {
+		fmt.Fprintf(w, "%s\t", s)
+		for _, e := range engines {
+			if v, ok := cells[s][e]; ok {
+				fmt.Fprintf(w, "%.0f\t", v)
+			} else {
+				fmt.Fprint(w, "-\t")
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	fmt.Printf("=====================================\n")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// 결과를 JSON 파일로 저장합니다
+func WriteResultsJSON(path string, results []Result) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// 결과를 CSV 파일로 저장합니다
+func WriteResultsCSV(path string, results []Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"engine", "scenario", "workers", "keySize", "valueSize", "syncWrites",
+		"writeMode", "batchSize", "keyDist",
+		"totalOps", "readOps", "writeOps", "scanOps", "errors", "elapsedMs", "opsPerSec", "writeCostPerKeyUs",
+		"readP50Us", "readP90Us", "readP99Us", "readP999Us", "readMaxUs",
+		"writeP50Us", "writeP90Us", "writeP99Us", "writeP999Us", "writeMaxUs",
+		"scanP50Us", "scanP99Us", "rmwP50Us", "rmwP99Us"})
+	for _, r := range results {
+		w.Write([]string{
+			r.Engine,
+			r.Scenario,
+			strconv.Itoa(r.Workers),
+			strconv.Itoa(r.KeySize),
+			strconv.Itoa(r.ValueSize),
+			strconv.FormatBool(r.SyncWrites),
+			r.WriteMode,
+			strconv.Itoa(r.BatchSize),
+			r.KeyDist,
+			strconv.FormatUint(r.TotalOps, 10),
+			strconv.FormatUint(r.ReadOps, 10),
+			strconv.FormatUint(r.WriteOps, 10),
+			strconv.FormatUint(r.ScanOps, 10),
+			strconv.FormatUint(r.Errors, 10),
+			strconv.FormatFloat(r.ElapsedMs, 'f', 3, 64),
+			strconv.FormatFloat(r.OpsPerSec, 'f', 2, 64),
+			strconv.FormatFloat(r.WriteCostPerKeyUs, 'f', 3, 64),
+			formatMicros(r.ReadLatency.P50),
+			formatMicros(r.ReadLatency.P90),
+			formatMicros(r.ReadLatency.P99),
+			formatMicros(r.ReadLatency.P999),
+			formatMicros(r.ReadLatency.Max),
+			formatMicros(r.WriteLatency.P50),
+			formatMicros(r.WriteLatency.P90),
+			formatMicros(r.WriteLatency.P99),
+			formatMicros(r.WriteLatency.P999),
+			formatMicros(r.WriteLatency.Max),
+			formatMicros(r.ScanLatency.P50),
+			formatMicros(r.ScanLatency.P99),
+			formatMicros(r.RMWLatency.P50),
+			formatMicros(r.RMWLatency.P99),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func formatMicros(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
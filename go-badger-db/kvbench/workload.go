@@ -0,0 +1,366 @@
+package kvbench
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 키 분포 종류
+const (
+	KeyDistUniform = "uniform" // 모든 키를 같은 확률로 접근
+	KeyDistZipfian = "zipfian" // 소수의 인기 키에 접근이 몰림 (YCSB scrambled zipfian)
+	KeyDistLatest  = "latest"  // 최근에 추가된 키일수록 자주 접근
+	KeyDistHotspot = "hotspot" // 키의 hotSetFraction 에 접근의 hotOpFraction 이 집중
+)
+
+// 값 크기 분포 종류
+const (
+	ValueDistFixed   = "fixed"
+	ValueDistUniform = "uniform"
+	ValueDistZipfian = "zipfian" // 작은 값이 많고 큰 값은 드묾
+)
+
+const (
+	// YCSB 기본 zipfian 상수
+	zipfianTheta = 0.99
+
+	// hotspot 분포: 키의 20%가 접근의 80%를 차지
+	hotSetFraction = 0.2
+	hotOpFraction  = 0.8
+)
+
+// keyChooser 는 [0, count) 범위에서 다음에 접근할 키 인덱스를 고릅니다
+// count 는 현재까지 저장된 레코드 수이며, insert 가 있는 워크로드에서는 계속 증가합니다
+type keyChooser interface {
+	Next(r *rand.Rand, count int) int
+}
+
+// 분포 이름에 맞는 keyChooser 를 만듭니다 (recordCount 는 zipfian 계산에 쓰는 초기 레코드 수)
+func newKeyChooser(dist string, recordCount int) (keyChooser, error) {
+	if recordCount < 1 {
+		recordCount = 1
+	}
+	switch dist {
+	case KeyDistUniform:
+		return uniformChooser{}, nil
+	case KeyDistZipfian:
+		return &scrambledZipfianChooser{zipf: newZipfianGenerator(recordCount, zipfianTheta)}, nil
+	case KeyDistLatest:
+		return &latestChooser{zipf: newZipfianGenerator(recordCount, zipfianTheta)}, nil
+	case KeyDistHotspot:
+		return hotspotChooser{hotSet: hotSetFraction, hotOps: hotOpFraction}, nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 키 분포: %s", dist)
+	}
+}
+
+// uniformChooser 는 모든 키를 같은 확률로 고릅니다
+type uniformChooser struct{}
+
+func (uniformChooser) Next(r *rand.Rand, count int) int {
+	return r.Intn(count)
+}
+
+// zipfianGenerator 는 Gray et al. "Quickly Generating Billion-Record Synthetic Databases"
+// 방식의 zipfian 순위 생성기입니다 (YCSB ZipfianGenerator 와 동일한 계산)
+type zipfianGenerator struct {
+	items int
+	theta float64
+	zetan float64
+	alpha float64
+	eta   float64
+}
+
+func newZipfianGenerator(items int, theta float64) *zipfianGenerator {
+	zeta2 := zeta(2, theta)
+	zetan := zeta(items, theta)
+	return &zipfianGenerator{
+		items: items,
+		theta: theta,
+		zetan: zetan,
+		alpha: 1 / (1 - theta),
+		eta:   (1 - math.Pow(2/float64(items), 1-theta)) / (1 - zeta2/zetan),
+	}
+}
+
+func zeta(n int, theta float64) float64 {
+	sum := 0.0
+	for i := 1; i <= n; i++ {
+		sum += 1 / math.Pow(float64(i), theta)
+	}
+	return sum
+}
+
+// rank 는 u(0~1 균등 난수)를 0 이 가장 인기 있는 순위로 변환합니다
+func (z *zipfianGenerator) rank(u float64) int {
+	uz := u * z.zetan
+	if uz < 1 {
+		return 0
+	}
+	if uz < 1+math.Pow(0.5, z.theta) {
+		return 1
+	}
+	r := int(float64(z.items) * math.Pow(z.eta*u-z.eta+1, z.alpha))
+	if r >= z.items {
+		r = z.items - 1
+	}
+	return r
+}
+
+// scrambledZipfianChooser 는 인기 순위를 해시로 흩어서 인기 키가 한 구간에 몰리지 않게 합니다
+type scrambledZipfianChooser struct {
+	zipf *zipfianGenerator
+}
+
+func (c *scrambledZipfianChooser) Next(r *rand.Rand, count int) int {
+	return int(fnvHash64(uint64(c.zipf.rank(r.Float64()))) % uint64(count))
+}
+
+// latestChooser 는 가장 최근 키(count-1)가 가장 인기 있는 zipfian 분포입니다
+type latestChooser struct {
+	zipf *zipfianGenerator
+}
+
+func (c *latestChooser) Next(r *rand.Rand, count int) int {
+	idx := count - 1 - c.zipf.rank(r.Float64())
+	if idx < 0 {
+		return 0
+	}
+	return idx
+}
+
+// hotspotChooser 는 앞쪽 hotSet 비율의 키에 hotOps 비율의 접근을 몰아줍니다
+type hotspotChooser struct {
+	hotSet float64
+	hotOps float64
+}
+
+func (c hotspotChooser) Next(r *rand.Rand, count int) int {
+	hot := int(float64(count) * c.hotSet)
+	if hot < 1 {
+		hot = 1
+	}
+	if r.Float64() < c.hotOps || count <= hot {
+		return r.Intn(hot)
+	}
+	return hot + r.Intn(count-hot)
+}
+
+func fnvHash64(v uint64) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	for i := range buf {
+		buf[i] = byte(v >> (8 * i))
+	}
+	h.Write(buf[:])
+	return h.Sum64()
+}
+
+// valueSizer 는 u(0~1 균등 난수)를 값 크기로 변환합니다
+type valueSizer interface {
+	Size(u float64) int
+}
+
+// 분포 이름에 맞는 valueSizer 를 만듭니다
+func newValueSizer(dist string, min, max int) (valueSizer, error) {
+	if dist == ValueDistFixed {
+		return nil, nil
+	}
+	if min < 3 || max < min {
+		return nil, fmt.Errorf("잘못된 값 크기 범위: %d~%d", min, max)
+	}
+	switch dist {
+	case ValueDistUniform:
+		return uniformValueSizer{min: min, max: max}, nil
+	case ValueDistZipfian:
+		return &zipfianValueSizer{min: min, zipf: newZipfianGenerator(max-min+1, zipfianTheta)}, nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 값 크기 분포: %s", dist)
+	}
+}
+
+type uniformValueSizer struct {
+	min, max int
+}
+
+func (s uniformValueSizer) Size(u float64) int {
+	return s.min + int(u*float64(s.max-s.min+1))
+}
+
+type zipfianValueSizer struct {
+	min  int
+	zipf *zipfianGenerator
+}
+
+func (s *zipfianValueSizer) Size(u float64) int {
+	return s.min + s.zipf.rank(u)
+}
+
+// 인덱스로부터 결정적인 0~1 균등 난수를 만듭니다 (같은 키는 항상 같은 값 크기)
+func indexUniform(idx int) float64 {
+	return float64(fnvHash64(uint64(idx))>>11) / (1 << 53)
+}
+
+// YCSBWorkload 는 YCSB 코어 워크로드의 작업 비율과 키 분포입니다
+type YCSBWorkload struct {
+	name             string
+	readProportion   float64
+	updateProportion float64
+	insertProportion float64
+	scanProportion   float64
+	rmwProportion    float64
+	keyDist          string
+	maxScanLength    int
+}
+
+// YCSB 코어 워크로드 A~F
+var YCSBWorkloads = []YCSBWorkload{
+	{name: "A", readProportion: 0.5, updateProportion: 0.5, keyDist: KeyDistZipfian},                       // 업데이트 위주 (세션 저장소)
+	{name: "B", readProportion: 0.95, updateProportion: 0.05, keyDist: KeyDistZipfian},                     // 읽기 위주 (사진 태깅)
+	{name: "C", readProportion: 1.0, keyDist: KeyDistZipfian},                                              // 읽기 전용 (종목 마스터 조회)
+	{name: "D", readProportion: 0.95, insertProportion: 0.05, keyDist: KeyDistLatest},                      // 최신 데이터 읽기 (상태 업데이트)
+	{name: "E", scanProportion: 0.95, insertProportion: 0.05, keyDist: KeyDistZipfian, maxScanLength: 100}, // 짧은 범위 스캔
+	{name: "F", readProportion: 0.5, rmwProportion: 0.5, keyDist: KeyDistZipfian},                          // read-modify-write
+}
+
+// 이름(A~F)으로 YCSB 워크로드를 찾습니다
+func YCSBPreset(name string) (YCSBWorkload, error) {
+	for _, w := range YCSBWorkloads {
+		if w.name == name {
+			return w, nil
+		}
+	}
+	return YCSBWorkload{}, fmt.Errorf("알 수 없는 YCSB 워크로드: %s", name)
+}
+
+// 혼합/YCSB 테스트의 키 분포를 설정합니다 (recordCount 는 미리 로드할 레코드 수)
+func (t *BadgerIndividualWriteTest) SetKeyDistribution(dist string, recordCount int) error {
+	chooser, err := newKeyChooser(dist, recordCount)
+	if err != nil {
+		return err
+	}
+	t.keyDist = dist
+	t.keyChooser = chooser
+	return nil
+}
+
+// 쓰기 값의 크기 분포를 설정합니다 (fixed 이면 valueSize 고정)
+func (t *BadgerIndividualWriteTest) SetValueSizeDistribution(dist string, min, max int) error {
+	sizer, err := newValueSizer(dist, min, max)
+	if err != nil {
+		return err
+	}
+	t.valueSizer = sizer
+	return nil
+}
+
+// 범위 스캔 작업 수행 (idx 키부터 length 개)
+func (t *BadgerIndividualWriteTest) scanOperation(idx, length int, lat *workerLatency) error {
+	key := t.generateKey(idx)
+
+	start := time.Now()
+	_, err := t.engine.Scan(key, length, func(key, val []byte) error {
+		// 값 사용 (실제로는 아무것도 하지 않음)
+		_ = val
+		return nil
+	})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		atomic.AddUint64(&t.stats.scanOps, 1)
+		lat.scan.Record(elapsed)
+	} else {
+		atomic.AddUint64(&t.stats.errors, 1)
+	}
+	return err
+}
+
+// read-modify-write 작업 수행 (읽기와 쓰기는 각각 집계하고, 전체 지연시간은 따로 기록)
+func (t *BadgerIndividualWriteTest) rmwOperation(idx int, lat *workerLatency) error {
+	start := time.Now()
+	if err := t.readOperation(idx, lat); err != nil && err != errKVNotFound {
+		return err
+	}
+	err := t.writeOperation(idx, lat)
+	if err == nil {
+		lat.rmw.Record(time.Since(start))
+	}
+	return err
+}
+
+// YCSB 워크로드 워커
+func (t *BadgerIndividualWriteTest) workloadWorker(workerID int, wg *sync.WaitGroup, opsPerWorker int, w YCSBWorkload) {
+	defer wg.Done()
+
+	lat := newWorkerLatency()
+	defer t.mergeLatency(lat)
+
+	// 난수 생성기 초기화 (각 워커마다 다른 시드)
+	r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(workerID)))
+
+	for i := 0; t.keepRunning(i, t.untilDeadline(opsPerWorker)); i++ {
+		count := int(atomic.LoadInt64(&t.recordCount))
+		p := r.Float64()
+
+		switch {
+		case p < w.readProportion:
+			t.readOperation(t.keyChooser.Next(r, count), lat)
+		case p < w.readProportion+w.updateProportion:
+			t.writeOperation(t.keyChooser.Next(r, count), lat)
+		case p < w.readProportion+w.updateProportion+w.insertProportion:
+			// 새 레코드는 항상 키 공간의 끝에 추가
+			t.writeOperation(int(atomic.AddInt64(&t.recordCount, 1)-1), lat)
+		case p < w.readProportion+w.updateProportion+w.insertProportion+w.scanProportion:
+			t.scanOperation(t.keyChooser.Next(r, count), 1+r.Intn(w.maxScanLength), lat)
+		default:
+			t.rmwOperation(t.keyChooser.Next(r, count), lat)
+		}
+	}
+}
+
+// YCSB 워크로드 실행
+// 전체 작업 수의 10%를 레코드로 미리 로드한 뒤, 워크로드 비율대로 작업을 수행합니다
+func (t *BadgerIndividualWriteTest) RunWorkload(w YCSBWorkload) (time.Duration, error) {
+	recordCount := preloadCount(t.numOperations)
+	if err := t.preloadData(recordCount); err != nil {
+		return 0, err
+	}
+
+	// 키 분포를 따로 지정하지 않았으면 워크로드 기본 분포 사용
+	if t.keyChooser == nil {
+		if err := t.SetKeyDistribution(w.keyDist, recordCount); err != nil {
+			return 0, err
+		}
+	}
+
+	t.runWarmup(func() { t.runWorkload(w) })
+	return t.runWorkload(w), nil
+}
+
+// 워크로드 워커를 실행하고 경과 시간을 반환합니다
+func (t *BadgerIndividualWriteTest) runWorkload(w YCSBWorkload) time.Duration {
+	var wg sync.WaitGroup
+	opsPerWorker := t.numOperations / t.numWorkers
+
+	// 시작 시간 기록
+	startTime := t.start()
+	stopSampler := t.startThroughputSampler()
+
+	// 워커 고루틴 시작
+	for i := 0; i < t.numWorkers; i++ {
+		wg.Add(1)
+		go t.workloadWorker(i, &wg, opsPerWorker, w)
+	}
+
+	// 모든 워커가 완료될 때까지 대기
+	wg.Wait()
+	stopSampler()
+
+	// 경과 시간 계산
+	return time.Since(startTime)
+}
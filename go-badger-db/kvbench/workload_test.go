@@ -0,0 +1,140 @@
+package kvbench
+
+import (
+	"flag"
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+var (
+	kvBenchKeyDist   = flag.String("kvbench.keydist", "", "YCSB 워크로드의 키 분포를 덮어씀 (uniform, zipfian, latest, hotspot)")
+	kvBenchValueDist = flag.String("kvbench.valuedist", ValueDistFixed, "값 크기 분포 (fixed, uniform, zipfian)")
+	kvBenchValueMin  = flag.Int("kvbench.valuemin", 100, "값 크기 분포의 최소 크기 (bytes)")
+	kvBenchValueMax  = flag.Int("kvbench.valuemax", 1000, "값 크기 분포의 최대 크기 (bytes)")
+)
+
+// 테스트 함수 - YCSB 코어 워크로드 A~F 를 Badger, Pebble (디스크)에서 실행하고 비교
+func TestYCSBWorkloads(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	cpuCores := runtime.NumCPU()
+	ops := *kvBenchOps
+
+	var results []Result
+	for _, w := range YCSBWorkloads {
+		for _, kind := range []string{EngineBadger, EnginePebble} {
+			scenario := "YCSB-" + w.name
+			t.Run(scenario+"/"+kind, func(t *testing.T) {
+				test, err := NewIndividualWriteTest(kind, ops, cpuCores, 16, 100, false, false)
+				if err != nil {
+					t.Fatalf("테스트 초기화 실패: %v", err)
+				}
+				defer test.Cleanup()
+				test.SetRecordTimeSeries(*kvBenchTimeSeries)
+
+				if *kvBenchKeyDist != "" {
+					if err := test.SetKeyDistribution(*kvBenchKeyDist, ops/10); err != nil {
+						t.Fatalf("키 분포 설정 실패: %v", err)
+					}
+				}
+				if err := test.SetValueSizeDistribution(*kvBenchValueDist, *kvBenchValueMin, *kvBenchValueMax); err != nil {
+					t.Fatalf("값 크기 분포 설정 실패: %v", err)
+				}
+
+				elapsed, err := test.RunWorkload(w)
+				if err != nil {
+					t.Fatalf("워크로드 실행 실패: %v", err)
+				}
+				test.PrintResults(scenario, elapsed)
+				results = append(results, test.Result(scenario, elapsed))
+			})
+		}
+	}
+
+	PrintComparisonTable(results)
+
+	if *kvBenchOut == "" {
+		return
+	}
+	if err := WriteResultsJSON(*kvBenchOut+"_ycsb.json", results); err != nil {
+		t.Fatalf("JSON 결과 저장 실패: %v", err)
+	}
+	if err := WriteResultsCSV(*kvBenchOut+"_ycsb.csv", results); err != nil {
+		t.Fatalf("CSV 결과 저장 실패: %v", err)
+	}
+}
+
+// 테스트 함수 - 키 분포가 의도한 만큼 치우쳐 있는지 확인
+func TestKeyDistributions(t *testing.T) {
+	const count = 10000
+	const samples = 100000
+	r := rand.New(rand.NewSource(1))
+
+	// 상위 1% 키가 차지하는 접근 비율
+	topShare := func(c keyChooser) float64 {
+		hits := make([]int, count)
+		for i := 0; i < samples; i++ {
+			hits[c.Next(r, count)]++
+		}
+		// 접근 횟수 내림차순 상위 1%
+		sorted := append([]int(nil), hits...)
+		for i := 0; i < count/100; i++ {
+			maxIdx := i
+			for j := i + 1; j < count; j++ {
+				if sorted[j] > sorted[maxIdx] {
+					maxIdx = j
+				}
+			}
+			sorted[i], sorted[maxIdx] = sorted[maxIdx], sorted[i]
+		}
+		top := 0
+		for i := 0; i < count/100; i++ {
+			top += sorted[i]
+		}
+		return float64(top) / samples
+	}
+
+	uniform, _ := newKeyChooser(KeyDistUniform, count)
+	zipf, _ := newKeyChooser(KeyDistZipfian, count)
+	if share := topShare(uniform); share > 0.05 {
+		t.Errorf("uniform: top 1%% keys got %.2f of accesses", share)
+	}
+	if share := topShare(zipf); share < 0.3 {
+		t.Errorf("zipfian: top 1%% keys got only %.2f of accesses", share)
+	}
+
+	// latest 는 최근 키에, hotspot 은 앞쪽 20% 키에 접근이 몰려야 함
+	latest, _ := newKeyChooser(KeyDistLatest, count)
+	hotspot, _ := newKeyChooser(KeyDistHotspot, count)
+	recent, hot := 0, 0
+	for i := 0; i < samples; i++ {
+		if latest.Next(r, count) >= count-count/10 {
+			recent++
+		}
+		if hotspot.Next(r, count) < count/5 {
+			hot++
+		}
+	}
+	if share := float64(recent) / samples; share < 0.5 {
+		t.Errorf("latest: newest 10%% keys got only %.2f of accesses", share)
+	}
+	if share := float64(hot) / samples; share < 0.75 || share > 0.85 {
+		t.Errorf("hotspot: hot set got %.2f of accesses, expected ~%.2f", share, hotOpFraction)
+	}
+
+	// 값 크기는 항상 범위 안에 있어야 함
+	for _, dist := range []string{ValueDistUniform, ValueDistZipfian} {
+		sizer, err := newValueSizer(dist, 100, 1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 1000; i++ {
+			if size := sizer.Size(indexUniform(i)); size < 100 || size > 1000 {
+				t.Errorf("%s: value size %d out of range", dist, size)
+			}
+		}
+	}
+}
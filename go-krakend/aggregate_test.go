@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/yiminan/go-examples/go-krakend/gateway"
+)
+
+// krakend.json 에서 엔드포인트 설정을 찾습니다
+func loadEndpoint(t *testing.T, endpoint, method string) gateway.Endpoint {
+	t.Helper()
+	cfg, err := gateway.Load("krakend.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := cfg.FindEndpoint(method, endpoint)
+	if !ok {
+		t.Fatalf("krakend.json 에 %s %s 엔드포인트가 없습니다", method, endpoint)
+	}
+	return e
+}
+
+// 모듈 디렉터리(dir)의 main 패키지를 빌드해 실행하고, 테스트가 끝나면 종료합니다
+func startBackend(t *testing.T, dir, name string, args ...string) {
+	t.Helper()
+	bin := filepath.Join(t.TempDir(), name)
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = dir
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("%s 빌드 실패: %v\n%s", name, err, out)
+	}
+
+	cmd := exec.Command(bin, args...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("%s 실행 실패: %v", name, err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Signal(os.Interrupt)
+		done := make(chan struct{})
+		go func() {
+			cmd.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			cmd.Process.Kill()
+		}
+	})
+}
+
+// url 이 응답할 때까지 기다립니다
+func waitReady(t *testing.T, url string) {
+	t.Helper()
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		if resp, err := http.Get(url); err == nil {
+			resp.Body.Close()
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("%s 가 응답하지 않습니다", url)
+}
+
+// 테스트 함수 - 사용자 서비스와 종목 서버를 띄우고 /api/overview 병합 결과 확인
+// krakend 바이너리가 PATH 에 있으면 실제 Gateway 를, 없으면 gateway.Proxy 를 거칩니다
+func TestOverviewAggregation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	startBackend(t, ".", "users", "-addr", ":3001", "-data", filepath.Join(t.TempDir(), "users"))
+	startBackend(t, "../go-badger-db-and-grpc", "stock")
+	waitReady(t, "http://localhost:3001/users")
+	waitReady(t, "http://localhost:8081/get")
+
+	const stockKey = "stock:20250428:KR7005930003"
+	loadEndpoint(t, "/api/overview/{id}/{key}", http.MethodGet)
+
+	gatewayURL := "http://localhost:8080"
+	if krakend, err := exec.LookPath("krakend"); err == nil {
+		cmd := exec.Command(krakend, "run", "-c", "krakend.json")
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			cmd.Process.Kill()
+			cmd.Wait()
+		}()
+		waitReady(t, gatewayURL+"/__health")
+	} else {
+		t.Log("krakend 바이너리가 없어 krakend.json 을 해석하는 gateway.Proxy 를 사용합니다")
+		cfg, err := gateway.Load("krakend.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		proxy, err := gateway.NewProxy(cfg, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gw := httptest.NewServer(proxy)
+		defer gw.Close()
+		gatewayURL = gw.URL
+	}
+
+	resp, err := http.Get(gatewayURL + "/api/overview/1/" + stockKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var merged map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&merged); err != nil {
+		t.Fatal(err)
+	}
+
+	user, ok := merged["user"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("user 그룹 없음: %v", merged)
+	}
+	if user["name"] != "Alice" || user["id"] != "1" {
+		t.Errorf("user = %v", user)
+	}
+	if _, ok := user["createdAt"]; ok {
+		t.Errorf("allow 에 없는 필드가 포함됨: %v", user)
+	}
+
+	stock, ok := merged["stock"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("stock 그룹 없음: %v", merged)
+	}
+	if stock["ticker"] != "A005930" || stock["price"] != float64(49000) || stock["code"] != "KR7005930003" {
+		t.Errorf("stock = %v", stock)
+	}
+	for _, k := range []string{"shortCode", "close", "volume", "limitPrice"} {
+		if _, ok := stock[k]; ok {
+			t.Errorf("stock 에 %q 필드가 남아 있음", k)
+		}
+	}
+}
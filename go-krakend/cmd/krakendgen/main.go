@@ -0,0 +1,89 @@
+// krakendgen 은 서비스 레지스트리(services.yaml)로부터 krakend.json 을 생성하고 검증합니다
+//
+//	go run ./cmd/krakendgen                # krakend.json 생성
+//	go run ./cmd/krakendgen -check         # 저장된 krakend.json 이 생성 결과와 다르면 실패 (CI 용)
+//	go run ./cmd/krakendgen -check -ping   # 백엔드 host 에 연결되는지도 확인
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/yiminan/go-examples/go-krakend/gateway"
+)
+
+func main() {
+	registryPath := flag.String("registry", "services.yaml", "서비스 레지스트리 파일")
+	outPath := flag.String("out", "krakend.json", "생성할 KrakenD 설정 파일")
+	check := flag.Bool("check", false, "파일을 쓰지 않고, 저장된 설정이 생성 결과와 같은지 확인")
+	ping := flag.Bool("ping", false, "백엔드 host 에 TCP 연결이 되는지 확인")
+	flag.Parse()
+
+	registry, err := gateway.LoadRegistry(*registryPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cfg, err := registry.Generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+	// 재시도 설정은 krakend.json 에 쓰지 않지만 (Proxy 전용) 함께 검증
+	if _, err := registry.Retries(); err != nil {
+		log.Fatal(err)
+	}
+	if *ping {
+		if err := cfg.CheckReachable(2 * time.Second); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	generated, err := cfg.Marshal()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !*check {
+		if err := os.WriteFile(*outPath, generated, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s 생성 완료 (엔드포인트 %d개)\n", *outPath, len(cfg.Endpoints))
+		return
+	}
+
+	current, err := os.ReadFile(*outPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !bytes.Equal(current, generated) {
+		log.Fatalf("%s 이 %s 로 생성한 결과와 다릅니다 (첫 차이: %s). go run ./cmd/krakendgen 으로 다시 생성하세요",
+			*outPath, *registryPath, firstDiff(current, generated))
+	}
+	fmt.Printf("%s 은 %s 와 일치합니다\n", *outPath, *registryPath)
+}
+
+// 두 파일에서 처음으로 다른 줄을 설명합니다
+func firstDiff(current, generated []byte) string {
+	a := strings.Split(string(current), "\n")
+	b := strings.Split(string(generated), "\n")
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y string
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			return fmt.Sprintf("%d번째 줄 %q != %q", i+1, strings.TrimSpace(x), strings.TrimSpace(y))
+		}
+	}
+	return "없음"
+}
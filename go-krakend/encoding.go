@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/yiminan/go-examples/go-krakend/proto/generated"
+)
+
+// 응답 형식 (Accept 헤더로 선택)
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+	contentTypeMsgpack  = "application/msgpack"
+)
+
+// gzip 압축을 적용할 최소 응답 크기 (작은 응답은 압축 이득보다 오버헤드가 큼)
+const gzipMinSize = 512
+
+// 지원하는 Accept 값과 응답 형식
+var acceptTypes = map[string]string{
+	"application/json":       contentTypeJSON,
+	"application/*":          contentTypeJSON,
+	"*/*":                    contentTypeJSON,
+	"application/x-protobuf": contentTypeProtobuf,
+	"application/protobuf":   contentTypeProtobuf,
+	"application/msgpack":    contentTypeMsgpack,
+	"application/x-msgpack":  contentTypeMsgpack,
+}
+
+// protoMessager 는 protobuf 로 응답할 수 있는 응답 타입입니다
+type protoMessager interface {
+	protoMessage() proto.Message
+}
+
+// Accept 헤더에서 q 값이 가장 높은 지원 형식을 고릅니다
+// Accept 헤더가 없으면 JSON, 지원하는 형식이 없으면 빈 문자열을 반환합니다
+func negotiate(accept string) string {
+	if strings.TrimSpace(accept) == "" {
+		return contentTypeJSON
+	}
+
+	type candidate struct {
+		contentType string
+		q           float64
+		order       int
+	}
+	var candidates []candidate
+	for i, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		ct, ok := acceptTypes[mediaType]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{ct, q, i})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	// q 값이 같으면 헤더에 먼저 나온 형식 우선
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].contentType
+}
+
+// 응답 값을 형식에 맞게 직렬화합니다
+func marshalResponse(contentType string, v interface{}) ([]byte, error) {
+	switch contentType {
+	case contentTypeProtobuf:
+		if m, ok := v.(protoMessager); ok {
+			return proto.Marshal(m.protoMessage())
+		}
+		return proto.Marshal(&pb.Error{Error: "Unsupported response type"})
+	case contentTypeMsgpack:
+		var buf bytes.Buffer
+		enc := msgpack.NewEncoder(&buf)
+		enc.SetCustomStructTag("json") // JSON 과 같은 필드 이름 사용
+		err := enc.Encode(v)
+		return buf.Bytes(), err
+	default:
+		data, err := json.Marshal(v)
+		return append(data, '\n'), err
+	}
+}
+
+// 요청 헤더의 Accept-Encoding 에 gzip 이 포함되는지 확인합니다
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.TrimSpace(coding) != "gzip" {
+			continue
+		}
+		return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+	}
+	return false
+}
+
+// If-None-Match 헤더가 etag 와 일치하는지 확인합니다
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// 응답 작성
+// Accept 헤더로 형식(JSON, protobuf, msgpack)을 고르고, GET 의 200 응답에는 ETag 를 붙여
+// If-None-Match 가 일치하면 304 를 반환하며, Accept-Encoding 에 gzip 이 있으면 압축합니다
+func writeResponse(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	w.Header().Add("Vary", "Accept")
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	contentType := negotiate(r.Header.Get("Accept"))
+	if contentType == "" {
+		// 지원하지 않는 형식은 requireAcceptable 이 먼저 거르므로, 여기서는 상태 코드를 유지하고 JSON 으로 반환
+		contentType = contentTypeJSON
+	}
+
+	data, err := marshalResponse(contentType, v)
+	if err != nil {
+		log.Printf("failed to encode %s response: %v", contentType, err)
+		w.Header().Set("Content-Type", contentTypeJSON)
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"Failed to encode response"}` + "\n"))
+		return
+	}
+
+	// ETag 는 형식별 직렬화 결과로 계산 (형식이 다르면 다른 ETag)
+	// gzip 으로 보내는 본문은 다른 표현이므로 -gzip 을 붙여 구분 (같은 강한 ETag 면 캐시가 두 본문을 섞을 수 있음)
+	gzipped := len(data) >= gzipMinSize && acceptsGzip(r)
+	if status == http.StatusOK && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+		sum := sha256.Sum256(data)
+		etag := hex.EncodeToString(sum[:16])
+		if gzipped {
+			etag += "-gzip"
+		}
+		etag = `"` + etag + `"`
+		w.Header().Set("ETag", etag)
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	if gzipped {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(data)
+		zw.Close()
+		data = buf.Bytes()
+		w.Header().Set("Content-Encoding", "gzip")
+	}
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		if _, err := w.Write(data); err != nil {
+			log.Printf("failed to write response: %v", err)
+		}
+	}
+}
+
+// 응답 형식을 고를 수 없는 Accept 헤더면 요청을 처리하기 전에 406 을 반환합니다 (에러 본문은 JSON)
+func requireAcceptable(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if negotiate(r.Header.Get("Accept")) == "" {
+			writeError(w, r, http.StatusNotAcceptable, "Not acceptable: supported types are application/json, application/x-protobuf, application/msgpack")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// 에러 응답 작성
+func writeError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	writeResponse(w, r, status, errorResponse{Error: msg})
+}
+
+// 405 응답 작성 (Allow 헤더에 허용 메서드 표시)
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func (u User) protoMessage() proto.Message {
+	return u.toProto()
+}
+
+func (u User) toProto() *pb.User {
+	return &pb.User{
+		Id:        u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		CreatedAt: formatTime(u.CreatedAt),
+		UpdatedAt: formatTime(u.UpdatedAt),
+	}
+}
+
+func (l userList) protoMessage() proto.Message {
+	m := &pb.UserList{Total: int32(l.Total), Offset: int32(l.Offset), Limit: int32(l.Limit)}
+	for _, u := range l.Users {
+		m.Users = append(m.Users, u.toProto())
+	}
+	return m
+}
+
+func (e errorResponse) protoMessage() proto.Message {
+	return &pb.Error{Error: e.Error, Fields: e.Fields}
+}
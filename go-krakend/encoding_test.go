@@ -0,0 +1,238 @@
+package main
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/yiminan/go-examples/go-krakend/proto/generated"
+)
+
+// 헤더를 지정해 GET 요청을 보내고 응답과 (압축 해제 전) 본문을 반환합니다
+func get(t *testing.T, url string, header map[string]string) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	// Accept-Encoding 을 직접 지정하면 Transport 가 자동으로 압축을 풀지 않음
+	if _, ok := header["Accept-Encoding"]; !ok {
+		req.Header.Set("Accept-Encoding", "identity")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, body
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", contentTypeJSON},
+		{"*/*", contentTypeJSON},
+		{"application/x-protobuf", contentTypeProtobuf},
+		{"application/msgpack;q=0.9, application/json;q=0.5", contentTypeMsgpack},
+		{"application/json;q=0.5, application/x-msgpack", contentTypeMsgpack},
+		{"text/html, application/protobuf;q=0.1", contentTypeProtobuf},
+		{"application/json;q=0", ""},
+		{"text/html", ""},
+	}
+	for _, tt := range tests {
+		if got := negotiate(tt.accept); got != tt.want {
+			t.Errorf("negotiate(%q) = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestUsersContentNegotiation(t *testing.T) {
+	srv := newTestServer(t)
+	if err := seedUsers(); err != nil {
+		t.Fatal(err)
+	}
+
+	// protobuf
+	resp, body := get(t, srv.URL+"/users", map[string]string{"Accept": contentTypeProtobuf})
+	if ct := resp.Header.Get("Content-Type"); ct != contentTypeProtobuf {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	var list pb.UserList
+	if err := proto.Unmarshal(body, &list); err != nil {
+		t.Fatalf("protobuf 디코딩 실패: %v", err)
+	}
+	if list.Total != 1 || len(list.Users) != 1 || list.Users[0].Name != "Alice" {
+		t.Errorf("protobuf 목록 = %v", &list)
+	}
+
+	// msgpack (JSON 과 같은 필드 이름)
+	resp, body = get(t, srv.URL+"/users/1", map[string]string{"Accept": contentTypeMsgpack})
+	if ct := resp.Header.Get("Content-Type"); ct != contentTypeMsgpack {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	var user map[string]interface{}
+	if err := msgpack.Unmarshal(body, &user); err != nil {
+		t.Fatalf("msgpack 디코딩 실패: %v", err)
+	}
+	if user["name"] != "Alice" || user["email"] != "alice@example.com" {
+		t.Errorf("msgpack 사용자 = %v", user)
+	}
+
+	// 지원하지 않는 형식
+	resp, _ = get(t, srv.URL+"/users", map[string]string{"Accept": "text/html"})
+	if resp.StatusCode != http.StatusNotAcceptable {
+		t.Errorf("text/html: status %d, want 406", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != contentTypeJSON {
+		t.Errorf("406 Content-Type = %q", ct)
+	}
+}
+
+func TestUsersNotAcceptableBeforeWrite(t *testing.T) {
+	srv := newTestServer(t)
+	if err := seedUsers(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 406 이면 생성, 수정, 삭제하지 않음
+	requests := []struct{ method, path, body string }{
+		{http.MethodPost, "/users", `{"name":"Bob","email":"bob@example.com"}`},
+		{http.MethodPost, "/users", `{"name":""}`}, // 검증 전에 406
+		{http.MethodPut, "/users/1", `{"name":"Alice Kim","email":"alice@example.com"}`},
+		{http.MethodDelete, "/users/1", ""},
+	}
+	for _, rq := range requests {
+		req, err := http.NewRequest(rq.method, srv.URL+rq.path, strings.NewReader(rq.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "text/html")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotAcceptable {
+			t.Errorf("%s %s: status %d, want 406", rq.method, rq.path, resp.StatusCode)
+		}
+	}
+
+	var list userList
+	doJSON(t, http.MethodGet, srv.URL+"/users", "", &list)
+	if list.Total != 1 || list.Users[0].Name != "Alice" {
+		t.Errorf("users = %+v", list)
+	}
+}
+
+func TestUsersETag(t *testing.T) {
+	srv := newTestServer(t)
+	if err := seedUsers(); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, _ := get(t, srv.URL+"/users/1", nil)
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag 없음")
+	}
+
+	resp, body := get(t, srv.URL+"/users/1", map[string]string{"If-None-Match": etag})
+	if resp.StatusCode != http.StatusNotModified || len(body) != 0 {
+		t.Fatalf("If-None-Match: status %d, body %q", resp.StatusCode, body)
+	}
+
+	// 형식이 다르면 ETag 도 다름
+	resp, _ = get(t, srv.URL+"/users/1", map[string]string{"If-None-Match": etag, "Accept": contentTypeMsgpack})
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("다른 형식: status %d, want 200", resp.StatusCode)
+	}
+
+	// 수정되면 ETag 가 바뀜
+	doJSON(t, http.MethodPut, srv.URL+"/users/1", `{"name":"Alice Kim","email":"alice@example.com"}`, nil)
+	resp, _ = get(t, srv.URL+"/users/1", map[string]string{"If-None-Match": etag})
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("수정 후: status %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestUsersGzip(t *testing.T) {
+	srv := newTestServer(t)
+	for i := 0; i < 10; i++ {
+		doJSON(t, http.MethodPost, srv.URL+"/users", `{"name":"User","email":"user@example.com"}`, nil)
+	}
+
+	resp, body := get(t, srv.URL+"/users", map[string]string{"Accept-Encoding": "gzip"})
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding = %q", resp.Header.Get("Content-Encoding"))
+	}
+	zr, err := gzip.NewReader(strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := io.ReadAll(zr)
+	if err != nil || !strings.Contains(string(plain), `"total":10`) {
+		t.Errorf("압축 해제 결과 = %q, err %v", plain, err)
+	}
+
+	// 작은 응답은 압축하지 않음
+	resp, _ = get(t, srv.URL+"/users/1", map[string]string{"Accept-Encoding": "gzip"})
+	if resp.Header.Get("Content-Encoding") != "" {
+		t.Errorf("작은 응답 Content-Encoding = %q", resp.Header.Get("Content-Encoding"))
+	}
+
+	// 압축한 본문과 원본은 ETag 가 다르고, 각자의 ETag 로만 304
+	gzipETag := encodedETag(t, srv.URL+"/users", "gzip")
+	identityETag := encodedETag(t, srv.URL+"/users", "identity")
+	if gzipETag == identityETag || !strings.HasSuffix(gzipETag, `-gzip"`) {
+		t.Errorf("ETag: gzip %s, identity %s", gzipETag, identityETag)
+	}
+	for _, c := range []struct {
+		encoding, etag string
+		status         int
+	}{
+		{"gzip", gzipETag, http.StatusNotModified},
+		{"identity", identityETag, http.StatusNotModified},
+		{"identity", gzipETag, http.StatusOK},
+		{"gzip", identityETag, http.StatusOK},
+	} {
+		resp, _ := get(t, srv.URL+"/users", map[string]string{"Accept-Encoding": c.encoding, "If-None-Match": c.etag})
+		if resp.StatusCode != c.status {
+			t.Errorf("%s with %s: status %d, want %d", c.encoding, c.etag, resp.StatusCode, c.status)
+		}
+	}
+}
+
+// Accept-Encoding 을 지정해 받은 응답의 ETag
+func encodedETag(t *testing.T, url, encoding string) string {
+	t.Helper()
+	resp, _ := get(t, url, map[string]string{"Accept-Encoding": encoding})
+	return resp.Header.Get("ETag")
+}
+
+func TestUsersMethodNotAllowed(t *testing.T) {
+	srv := newTestServer(t)
+
+	var errResp errorResponse
+	resp := doJSON(t, http.MethodPatch, srv.URL+"/users/1", "{}", &errResp)
+	if resp.StatusCode != http.StatusMethodNotAllowed || errResp.Error == "" {
+		t.Fatalf("status %d, body %+v", resp.StatusCode, errResp)
+	}
+	if allow := resp.Header.Get("Allow"); allow != "GET, HEAD, PUT, DELETE" {
+		t.Errorf("Allow = %q", allow)
+	}
+}
@@ -0,0 +1,125 @@
+package gateway
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// 서킷이 열려 백엔드를 호출하지 않은 경우의 에러
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// 서킷 브레이커 상태
+type breakerState int
+
+const (
+	stateClosed   breakerState = iota // 정상 호출
+	stateOpen                         // 호출 차단
+	stateHalfOpen                     // 요청 하나로 복구 여부 확인
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker 는 백엔드 하나의 qos/circuit-breaker 를 흉내냅니다
+type circuitBreaker struct {
+	name     string
+	cfg      CircuitBreakerConfig
+	now      func() time.Time
+	onChange func(name string, from, to breakerState)
+
+	mu          sync.Mutex
+	state       breakerState
+	failures    int       // interval 안의 연속 에러 수
+	windowStart time.Time // 연속 에러를 세기 시작한 시각
+	openedAt    time.Time
+	probing     bool   // half-open 에서 확인 요청이 진행 중
+	generation  uint64 // 상태가 바뀔 때마다 증가 (이전 상태에서 허용한 호출의 결과를 가려냄)
+}
+
+func newCircuitBreaker(cfg CircuitBreakerConfig, now func() time.Time, onChange func(string, breakerState, breakerState)) *circuitBreaker {
+	return &circuitBreaker{name: cfg.Name, cfg: cfg, now: now, onChange: onChange}
+}
+
+// 상태를 바꾸고 변경을 알립니다 (mu 를 잡은 상태에서 호출)
+func (cb *circuitBreaker) setState(to breakerState) {
+	from := cb.state
+	cb.state = to
+	cb.failures = 0
+	cb.probing = false
+	cb.generation++
+	if to == stateOpen {
+		cb.openedAt = cb.now()
+	}
+	if cb.cfg.LogStatusChange {
+		log.Printf("[CIRCUIT-BREAKER] %s: %s -> %s", cb.name, from, to)
+	}
+	if cb.onChange != nil {
+		cb.onChange(cb.name, from, to)
+	}
+}
+
+// 백엔드를 호출해도 되는지 확인하고, 결과를 기록할 때 넘길 세대를 반환합니다
+// 열린 상태에서 timeout 이 지나면 half-open 으로 바꾸고 확인 요청 하나만 허용합니다
+func (cb *circuitBreaker) allow() (uint64, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case stateOpen:
+		if cb.now().Sub(cb.openedAt) < time.Duration(cb.cfg.Timeout)*time.Second {
+			return 0, false
+		}
+		cb.setState(stateHalfOpen)
+		cb.probing = true
+		return cb.generation, true
+	case stateHalfOpen:
+		if cb.probing {
+			return 0, false
+		}
+		cb.probing = true
+		return cb.generation, true
+	default:
+		return cb.generation, true
+	}
+}
+
+// allow 가 반환한 세대의 호출 결과를 기록합니다
+// 상태가 바뀐 뒤에 끝난 호출(예: 닫혀 있을 때 허용되어 half-open 에서 끝난 요청)은 무시하므로
+// half-open 에서는 확인 요청의 결과만 상태를 바꿉니다
+func (cb *circuitBreaker) record(generation uint64, success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if generation != cb.generation {
+		return
+	}
+	switch cb.state {
+	case stateHalfOpen:
+		if success {
+			cb.setState(stateClosed)
+		} else {
+			cb.setState(stateOpen)
+		}
+	case stateClosed:
+		if success {
+			cb.failures = 0
+			return
+		}
+		now := cb.now()
+		if cb.failures == 0 || now.Sub(cb.windowStart) > time.Duration(cb.cfg.Interval)*time.Second {
+			cb.failures, cb.windowStart = 0, now
+		}
+		cb.failures++
+		if cb.failures > cb.cfg.MaxErrors {
+			cb.setState(stateOpen)
+		}
+	}
+}
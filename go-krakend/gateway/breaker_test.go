@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	var transitions []string
+	cb := newCircuitBreaker(CircuitBreakerConfig{Interval: 60, Timeout: 10, MaxErrors: 2},
+		func() time.Time { return now },
+		func(_ string, from, to breakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		})
+
+	// 연속 에러가 max_errors(2) 를 넘어야 열림, 중간에 성공하면 다시 셈
+	for _, success := range []bool{false, false, true, false, false} {
+		gen, ok := cb.allow()
+		if !ok {
+			t.Fatal("닫힌 상태에서 거절됨")
+		}
+		cb.record(gen, success)
+	}
+	if cb.state != stateClosed {
+		t.Fatalf("state = %v, want closed", cb.state)
+	}
+	gen, _ := cb.allow()
+	cb.record(gen, false)
+	if _, ok := cb.allow(); cb.state != stateOpen || ok {
+		t.Fatalf("state = %v, want open", cb.state)
+	}
+
+	// timeout 이 지나면 half-open 에서 확인 요청 하나만 허용
+	now = now.Add(10 * time.Second)
+	probe, ok := cb.allow()
+	if !ok {
+		t.Fatal("timeout 후 확인 요청이 거절됨")
+	}
+	if _, ok := cb.allow(); ok {
+		t.Fatal("half-open 에서 두 번째 요청이 허용됨")
+	}
+	cb.record(probe, false) // 확인 실패 → 다시 열림
+	if cb.state != stateOpen {
+		t.Fatalf("state = %v, want open", cb.state)
+	}
+
+	now = now.Add(10 * time.Second)
+	probe, _ = cb.allow()
+	cb.record(probe, true) // 확인 성공 → 닫힘
+	if cb.state != stateClosed {
+		t.Fatalf("state = %v, want closed", cb.state)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transitions = %v, want %v", transitions, want)
+			break
+		}
+	}
+}
+
+func TestCircuitBreakerLateResult(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cb := newCircuitBreaker(CircuitBreakerConfig{Interval: 60, Timeout: 10, MaxErrors: 0}, func() time.Time { return now }, nil)
+
+	// 닫혀 있을 때 세 요청이 허용되고 그중 하나가 실패해 열림 (나머지 둘은 아직 진행 중)
+	slowOK, _ := cb.allow()
+	slowFail, _ := cb.allow()
+	gen, _ := cb.allow()
+	cb.record(gen, false)
+	if cb.state != stateOpen {
+		t.Fatalf("state = %v, want open", cb.state)
+	}
+
+	now = now.Add(10 * time.Second)
+	probe, ok := cb.allow()
+	if !ok {
+		t.Fatal("timeout 후 확인 요청이 거절됨")
+	}
+	// 확인 요청이 끝나기 전에 도착한 이전 요청의 결과는 상태를 바꾸지 않음
+	cb.record(slowOK, true)
+	if cb.state != stateHalfOpen {
+		t.Fatalf("늦은 성공 후 state = %v, want half-open", cb.state)
+	}
+	cb.record(slowFail, false)
+	if cb.state != stateHalfOpen {
+		t.Fatalf("늦은 실패 후 state = %v, want half-open", cb.state)
+	}
+	cb.record(probe, true)
+	if cb.state != stateClosed {
+		t.Fatalf("state = %v, want closed", cb.state)
+	}
+}
+
+func TestCircuitBreakerInterval(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cb := newCircuitBreaker(CircuitBreakerConfig{Interval: 1, Timeout: 10, MaxErrors: 1}, func() time.Time { return now }, nil)
+
+	// interval 이 지난 에러는 연속 에러로 세지 않음
+	cb.record(0, false)
+	now = now.Add(2 * time.Second)
+	cb.record(0, false)
+	if cb.state != stateClosed {
+		t.Fatalf("state = %v, want closed", cb.state)
+	}
+	cb.record(0, false)
+	if cb.state != stateOpen {
+		t.Fatalf("state = %v, want open", cb.state)
+	}
+}
@@ -0,0 +1,134 @@
+// Package gateway 는 KrakenD 설정(krakend.json)의 생성, 로드, 검증을 담당합니다
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+)
+
+// Config 는 krakend.json 의 최상위 설정입니다 (이 저장소에서 사용하는 항목만 정의)
+type Config struct {
+	Version     int                 `json:"version"`
+	Name        string              `json:"name"`
+	Port        int                 `json:"port"`
+	Timeout     string              `json:"timeout"`
+	ExtraConfig *ServiceExtraConfig `json:"extra_config,omitempty"`
+	Endpoints   []Endpoint          `json:"endpoints"`
+}
+
+// ServiceExtraConfig 는 서비스 전체에 적용되는 extra_config 입니다
+type ServiceExtraConfig struct {
+	Metrics *MetricsConfig `json:"telemetry/metrics,omitempty"`
+	Logging *LoggingConfig `json:"telemetry/logging,omitempty"`
+}
+
+// MetricsConfig 는 telemetry/metrics 설정입니다
+type MetricsConfig struct {
+	CollectionTime string `json:"collection_time" yaml:"collection_time"`
+	ListenAddress  string `json:"listen_address" yaml:"listen_address"`
+}
+
+// LoggingConfig 는 telemetry/logging 설정입니다
+type LoggingConfig struct {
+	Level  string `json:"level" yaml:"level"`
+	Prefix string `json:"prefix" yaml:"prefix"`
+	Syslog bool   `json:"syslog" yaml:"syslog"`
+	Stdout bool   `json:"stdout" yaml:"stdout"`
+}
+
+// Endpoint 는 Gateway 가 노출하는 엔드포인트 하나입니다
+type Endpoint struct {
+	Endpoint          string    `json:"endpoint"`
+	Method            string    `json:"method"`
+	OutputEncoding    string    `json:"output_encoding,omitempty"`
+	InputQueryStrings []string  `json:"input_query_strings,omitempty"`
+	InputHeaders      []string  `json:"input_headers,omitempty"`
+	Timeout           string    `json:"timeout,omitempty"`
+	Backend           []Backend `json:"backend"`
+}
+
+// Backend 는 엔드포인트가 호출하는 백엔드 하나입니다
+type Backend struct {
+	Host        []string            `json:"host"`
+	URLPattern  string              `json:"url_pattern"`
+	Method      string              `json:"method"`
+	Encoding    string              `json:"encoding,omitempty"`
+	Group       string              `json:"group,omitempty"`
+	Allow       []string            `json:"allow,omitempty"`
+	Mapping     map[string]string   `json:"mapping,omitempty"`
+	ExtraConfig *BackendExtraConfig `json:"extra_config,omitempty"`
+}
+
+// BackendExtraConfig 는 백엔드별 extra_config 입니다
+type BackendExtraConfig struct {
+	HTTP           *BackendHTTPConfig    `json:"backend/http,omitempty"`
+	CircuitBreaker *CircuitBreakerConfig `json:"qos/circuit-breaker,omitempty"`
+	HTTPCache      *HTTPCacheConfig      `json:"qos/http-cache,omitempty"`
+}
+
+// BackendHTTPConfig 는 backend/http 설정입니다
+type BackendHTTPConfig struct {
+	// true 이면 백엔드의 에러 상태 코드를 그대로 클라이언트에 전달
+	ReturnErrorCode bool `json:"return_error_code"`
+}
+
+// CircuitBreakerConfig 는 qos/circuit-breaker 설정입니다
+// interval 초 안에 연속 에러가 max_errors 를 넘으면 timeout 초 동안 백엔드를 호출하지 않고,
+// 그 뒤 요청 하나로 상태를 확인(half-open)해 성공하면 다시 닫습니다
+type CircuitBreakerConfig struct {
+	Interval        int    `json:"interval" yaml:"interval"`
+	Timeout         int    `json:"timeout" yaml:"timeout"`
+	MaxErrors       int    `json:"max_errors" yaml:"max_errors"`
+	Name            string `json:"name,omitempty" yaml:"name"`
+	LogStatusChange bool   `json:"log_status_change" yaml:"log_status_change"`
+}
+
+// HTTPCacheConfig 는 qos/http-cache 설정입니다 (백엔드 응답의 Cache-Control 에 따라 KrakenD 가 응답을 캐시)
+type HTTPCacheConfig struct {
+	Shared bool `json:"shared" yaml:"shared"` // 같은 백엔드를 호출하는 엔드포인트끼리 캐시 공유
+}
+
+// RetryConfig 는 멱등 요청(GET, HEAD, PUT, DELETE)의 재시도 설정입니다
+// KrakenD CE 에는 백엔드 재시도 기능이 없어 krakend.json 에는 쓰지 않고,
+// services.yaml 의 서비스 설정을 in-process Proxy 에만 적용합니다 (Registry.Retries → Proxy.SetRetries)
+type RetryConfig struct {
+	MaxRetries int    `yaml:"max_retries"`
+	Backoff    string `yaml:"backoff"`     // 첫 재시도 전 최대 대기 시간 (재시도마다 두 배, jitter 적용)
+	MaxBackoff string `yaml:"max_backoff"` // 대기 시간 상한
+}
+
+// krakend.json 을 읽습니다
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// 설정을 krakend.json 형식(2칸 들여쓰기, 끝 줄바꿈)으로 직렬화합니다
+func (c *Config) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(c); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// 메서드와 경로로 엔드포인트를 찾습니다
+func (c *Config) FindEndpoint(method, endpoint string) (Endpoint, bool) {
+	for _, e := range c.Endpoints {
+		if e.Method == method && e.Endpoint == endpoint {
+			return e, true
+		}
+	}
+	return Endpoint{}, false
+}
@@ -0,0 +1,193 @@
+package gateway
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 검증을 통과하는 최소 설정
+func validConfig() *Config {
+	return &Config{
+		Version: 3,
+		Name:    "test",
+		Port:    8080,
+		Timeout: "3000ms",
+		Endpoints: []Endpoint{{
+			Endpoint: "/api/users/{id}",
+			Method:   "GET",
+			Backend: []Backend{{
+				Host:       []string{"http://localhost:3001"},
+				URLPattern: "/users/{id}",
+				Method:     "GET",
+			}},
+		}},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string // 에러 메시지에 포함돼야 하는 문자열 (빈 문자열이면 통과)
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"zero timeout", func(c *Config) { c.Timeout = "0s" }, "timeout"},
+		{"bad timeout", func(c *Config) { c.Timeout = "3000" }, "timeout"},
+		{"negative endpoint timeout", func(c *Config) { c.Endpoints[0].Timeout = "-1s" }, "timeout"},
+		{"unknown placeholder", func(c *Config) { c.Endpoints[0].Backend[0].URLPattern = "/users/{userId}" }, "{userId}"},
+		{"host with path", func(c *Config) { c.Endpoints[0].Backend[0].Host = []string{"http://localhost:3001/users"} }, "url_pattern"},
+		{"host without scheme", func(c *Config) { c.Endpoints[0].Backend[0].Host = []string{"localhost:3001"} }, "http"},
+		{"duplicate endpoint", func(c *Config) { c.Endpoints = append(c.Endpoints, c.Endpoints[0]) }, "중복"},
+		{"no-op encoding", func(c *Config) { c.Endpoints[0].OutputEncoding = "no-op" }, "no-op"},
+		{"unknown encoding", func(c *Config) { c.Endpoints[0].OutputEncoding = "yaml" }, "output_encoding"},
+		{"bad port", func(c *Config) { c.Port = 0 }, "port"},
+		{"circuit breaker", func(c *Config) {
+			c.Endpoints[0].Backend[0].ExtraConfig = &BackendExtraConfig{CircuitBreaker: &CircuitBreakerConfig{Interval: 60, MaxErrors: 1}}
+		}, "qos/circuit-breaker"},
+		{"metrics", func(c *Config) {
+			c.ExtraConfig = &ServiceExtraConfig{Metrics: &MetricsConfig{CollectionTime: "0s", ListenAddress: "8090"}}
+		}, "collection_time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validConfig()
+			tt.modify(c)
+			err := c.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var verr ValidationError
+			if !errors.As(err, &verr) || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateDefaults(t *testing.T) {
+	r := &Registry{
+		Name:     "test",
+		Port:     8080,
+		Timeout:  "3000ms",
+		Services: map[string]Service{"users": {Hosts: []string{"http://localhost:3001"}}},
+		Routes: []Route{{
+			Endpoint:       "/api/users",
+			Method:         "POST",
+			OutputEncoding: "no-op",
+			Backends:       []RouteBackend{{Service: "users", URLPattern: "/users", ReturnErrorCode: true}},
+		}},
+	}
+	c, err := r.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := c.Endpoints[0].Backend[0]
+	if b.Method != "POST" || b.Encoding != "no-op" || b.Host[0] != "http://localhost:3001" {
+		t.Errorf("backend = %+v", b)
+	}
+	if b.ExtraConfig == nil || b.ExtraConfig.HTTP == nil || !b.ExtraConfig.HTTP.ReturnErrorCode {
+		t.Errorf("return_error_code 가 extra_config 에 없음: %+v", b.ExtraConfig)
+	}
+
+	r.Routes[0].Backends[0].Service = "orders"
+	if _, err := r.Generate(); err == nil {
+		t.Error("등록되지 않은 서비스인데 에러가 없음")
+	}
+}
+
+// 재시도는 krakend.json 에 쓰지 않고 host 별 설정으로만 반환합니다
+func TestRegistryRetries(t *testing.T) {
+	retry := &RetryConfig{MaxRetries: 2, Backoff: "100ms", MaxBackoff: "1s"}
+	r := &Registry{
+		Name:     "test",
+		Port:     8080,
+		Timeout:  "3000ms",
+		Services: map[string]Service{"users": {Hosts: []string{"http://localhost:3001"}, Retry: retry}},
+		Routes: []Route{{
+			Endpoint: "/api/users/{id}",
+			Backends: []RouteBackend{{Service: "users", URLPattern: "/users/{id}"}},
+		}},
+	}
+	c, err := r.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := c.Marshal(); bytes.Contains(data, []byte("retry")) {
+		t.Errorf("krakend.json 에 재시도 설정이 있음:\n%s", data)
+	}
+	retries, err := r.Retries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := retries["http://localhost:3001"]; got != *retry {
+		t.Errorf("retries = %+v", retries)
+	}
+
+	retry.Backoff = "0s"
+	var verr ValidationError
+	if _, err := r.Retries(); !errors.As(err, &verr) || !strings.Contains(err.Error(), "users: retry backoff") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+// 저장된 krakend.json 이 services.yaml 로 생성한 결과와 같아야 합니다
+func TestCheckedInConfigUpToDate(t *testing.T) {
+	r, err := LoadRegistry("../services.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := r.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	generated, err := c.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile("../krakend.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(current, generated) {
+		t.Fatal("krakend.json 이 services.yaml 과 다릅니다. go run ./cmd/krakendgen 으로 다시 생성하세요")
+	}
+
+	// 다시 읽어도 같은 설정
+	loaded, err := Load("../krakend.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded.FindEndpoint("GET", "/api/overview/{id}/{key}"); !ok {
+		t.Error("overview 엔드포인트가 없음")
+	}
+}
+
+func TestCheckReachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	c := validConfig()
+	c.Endpoints[0].Backend[0].Host = []string{"http://" + ln.Addr().String()}
+	if err := c.CheckReachable(time.Second); err != nil {
+		t.Fatalf("열린 포트: %v", err)
+	}
+
+	ln.Close()
+	if err := c.CheckReachable(time.Second); err == nil {
+		t.Error("닫힌 포트인데 에러가 없음")
+	}
+}
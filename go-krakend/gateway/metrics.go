@@ -0,0 +1,160 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Metrics 는 Proxy 가 수집하는 엔드포인트/백엔드별 지표입니다
+// telemetry/metrics 의 listen_address 에서 /__stats 로 JSON 을 노출합니다
+type Metrics struct {
+	mu        sync.Mutex
+	started   time.Time
+	endpoints map[string]*EndpointStats
+	backends  map[string]*BackendStats
+}
+
+// EndpointStats 는 엔드포인트 하나의 누적 지표입니다
+type EndpointStats struct {
+	Requests     int64            `json:"requests"`
+	Statuses     map[string]int64 `json:"statuses"` // 상태 코드별 응답 수
+	Timeouts     int64            `json:"timeouts"`
+	Incomplete   int64            `json:"incomplete"` // 일부 백엔드만 성공한 응답 수
+	LatencyMsSum float64          `json:"latencyMsSum"`
+	LatencyMsMax float64          `json:"latencyMsMax"`
+}
+
+// BackendStats 는 백엔드 하나(method + host + url_pattern)의 누적 지표입니다
+type BackendStats struct {
+	Requests      int64  `json:"requests"` // 재시도를 포함한 호출 수
+	Errors        int64  `json:"errors"`   // 연결 실패, 타임아웃, 5xx
+	Timeouts      int64  `json:"timeouts"`
+	Retries       int64  `json:"retries"`
+	Rejected      int64  `json:"rejected"` // 서킷이 열려 호출하지 않은 수
+	CircuitState  string `json:"circuitState,omitempty"`
+	CircuitOpened int64  `json:"circuitOpened"` // 서킷이 열린 횟수
+}
+
+// MetricsSnapshot 은 /__stats 응답입니다
+type MetricsSnapshot struct {
+	UptimeSeconds float64                  `json:"uptimeSeconds"`
+	Endpoints     map[string]EndpointStats `json:"endpoints"`
+	Backends      map[string]BackendStats  `json:"backends"`
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		started:   time.Now(),
+		endpoints: make(map[string]*EndpointStats),
+		backends:  make(map[string]*BackendStats),
+	}
+}
+
+// 엔드포인트 응답 하나를 기록합니다
+func (m *Metrics) recordEndpoint(name string, status int, latency time.Duration, timedOut, incomplete bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.endpoints[name]
+	if !ok {
+		s = &EndpointStats{Statuses: make(map[string]int64)}
+		m.endpoints[name] = s
+	}
+	s.Requests++
+	s.Statuses[strconv.Itoa(status)]++
+	if timedOut {
+		s.Timeouts++
+	}
+	if incomplete {
+		s.Incomplete++
+	}
+	ms := float64(latency.Microseconds()) / 1000
+	s.LatencyMsSum += ms
+	if ms > s.LatencyMsMax {
+		s.LatencyMsMax = ms
+	}
+}
+
+// 백엔드 지표를 찾거나 만듭니다 (mu 를 잡은 상태에서 호출)
+func (m *Metrics) backend(name string) *BackendStats {
+	s, ok := m.backends[name]
+	if !ok {
+		s = &BackendStats{}
+		m.backends[name] = s
+	}
+	return s
+}
+
+// 백엔드 호출 하나를 기록합니다
+func (m *Metrics) recordBackend(name string, failed, timedOut bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.backend(name)
+	s.Requests++
+	if failed {
+		s.Errors++
+	}
+	if timedOut {
+		s.Timeouts++
+	}
+}
+
+// 재시도 하나를 기록합니다
+func (m *Metrics) recordRetry(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backend(name).Retries++
+}
+
+// 서킷이 열려 호출하지 않은 요청 하나를 기록합니다
+func (m *Metrics) recordRejected(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backend(name).Rejected++
+}
+
+// 서킷 브레이커 상태 변경을 기록합니다
+func (m *Metrics) recordCircuitState(name string, state breakerState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.backend(name)
+	s.CircuitState = state.String()
+	if state == stateOpen {
+		s.CircuitOpened++
+	}
+}
+
+// Snapshot 은 현재까지의 지표를 복사해 반환합니다
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snap := MetricsSnapshot{
+		UptimeSeconds: time.Since(m.started).Seconds(),
+		Endpoints:     make(map[string]EndpointStats, len(m.endpoints)),
+		Backends:      make(map[string]BackendStats, len(m.backends)),
+	}
+	for k, v := range m.endpoints {
+		s := *v
+		s.Statuses = make(map[string]int64, len(v.Statuses))
+		for code, n := range v.Statuses {
+			s.Statuses[code] = n
+		}
+		snap.Endpoints[k] = s
+	}
+	for k, v := range m.backends {
+		snap.Backends[k] = *v
+	}
+	return snap
+}
+
+// ServeHTTP 는 /__stats 로 지표를 JSON 으로 반환합니다
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/__stats" && r.URL.Path != "/__stats/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m.Snapshot())
+}
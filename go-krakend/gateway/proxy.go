@@ -0,0 +1,385 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Proxy 는 krakend.json 의 라우팅 규칙을 해석하는 in-process 리버스 프록시입니다
+// KrakenD 바이너리 없이 테스트하기 위한 대용품으로, 이 저장소에서 사용하는 항목만 흉내냅니다
+//   - endpoint/method 매칭, url_pattern 의 {param} 치환, input_query_strings/input_headers 필터링
+//   - timeout (엔드포인트 값이 없으면 서비스 전체 값)
+//   - no-op: 백엔드 응답(상태 코드, 헤더, 본문)을 그대로 전달
+//   - 그 외: 백엔드를 동시에 호출해 JSON 을 allow → mapping → group 순서로 병합
+//     (모든 백엔드가 실패하면 500, return_error_code 인 단일 백엔드면 에러 상태 코드를 그대로 전달)
+//   - qos/circuit-breaker: 연속 에러가 많으면 백엔드를 호출하지 않고 바로 실패
+//   - 재시도 (KrakenD 에는 없는 Proxy 전용 기능, SetRetries 로 지정): 멱등 요청의 연결 실패와 502/503/504 를
+//     jitter 를 준 지수 백오프로 재시도
+type Proxy struct {
+	cfg     *Config
+	hosts   map[string]string
+	client  *http.Client
+	mux     *http.ServeMux
+	metrics *Metrics
+	now     func() time.Time
+
+	// 백엔드별 서킷 브레이커 (같은 백엔드를 여러 엔드포인트가 호출해도 하나를 공유)
+	breakers map[string]*circuitBreaker
+	// 설정의 host 별 재시도 설정
+	retries map[string]RetryConfig
+}
+
+// NewProxy 는 설정을 검증하고 Proxy 를 만듭니다
+// hosts 는 설정의 host 를 다른 주소로 바꿀 때 사용합니다 (예: 테스트 서버의 임시 포트)
+func NewProxy(cfg *Config, hosts map[string]string) (*Proxy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	p := &Proxy{
+		cfg:      cfg,
+		hosts:    hosts,
+		client:   &http.Client{},
+		mux:      http.NewServeMux(),
+		metrics:  newMetrics(),
+		now:      time.Now,
+		breakers: make(map[string]*circuitBreaker),
+	}
+	p.mux.HandleFunc("GET /__health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"status":"ok"}`)
+	})
+	for _, e := range cfg.Endpoints {
+		timeout, err := time.ParseDuration(e.Timeout)
+		if e.Timeout == "" {
+			timeout, err = time.ParseDuration(cfg.Timeout)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rt := &route{proxy: p, endpoint: e, timeout: timeout, breakers: make([]*circuitBreaker, len(e.Backend))}
+		for i, b := range e.Backend {
+			if b.ExtraConfig == nil || b.ExtraConfig.CircuitBreaker == nil {
+				continue
+			}
+			name := backendName(b)
+			cb, ok := p.breakers[name]
+			if !ok {
+				cb = newCircuitBreaker(*b.ExtraConfig.CircuitBreaker,
+					func() time.Time { return p.now() },
+					func(_ string, _, to breakerState) { p.metrics.recordCircuitState(name, to) })
+				p.breakers[name] = cb
+				p.metrics.recordCircuitState(name, stateClosed)
+			}
+			rt.breakers[i] = cb
+		}
+		p.mux.Handle(e.Method+" "+e.Endpoint, rt)
+	}
+	return p, nil
+}
+
+// SetRetries 는 설정의 host 별 재시도 설정을 지정합니다 (요청을 받기 전에 호출, 보통 Registry.Retries 의 결과)
+// POST 등 멱등이 아닌 요청은 중복 생성될 수 있어 재시도하지 않습니다
+func (p *Proxy) SetRetries(retries map[string]RetryConfig) {
+	p.retries = retries
+}
+
+// ServeHTTP 는 설정의 엔드포인트로 요청을 전달합니다
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mux.ServeHTTP(w, r)
+}
+
+// Metrics 는 수집한 지표를 반환합니다 (listen_address 에서 노출할 핸들러로도 사용)
+func (p *Proxy) Metrics() *Metrics {
+	return p.metrics
+}
+
+// MetricsAddress 는 telemetry/metrics 의 listen_address 입니다 (설정이 없으면 빈 문자열)
+func (p *Proxy) MetricsAddress() string {
+	if p.cfg.ExtraConfig == nil || p.cfg.ExtraConfig.Metrics == nil {
+		return ""
+	}
+	return p.cfg.ExtraConfig.Metrics.ListenAddress
+}
+
+// 엔드포인트 하나의 처리기
+type route struct {
+	proxy    *Proxy
+	endpoint Endpoint
+	timeout  time.Duration
+	breakers []*circuitBreaker // 백엔드별 서킷 브레이커 (설정이 없으면 nil)
+}
+
+// 지표에서 백엔드를 구분하는 이름
+func backendName(b Backend) string {
+	return b.Method + " " + b.Host[0] + b.URLPattern
+}
+
+// 재시도해도 되는 메서드 (같은 요청을 여러 번 보내도 결과가 같음)
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// 재시도할 결과인지 확인합니다 (연결 실패, 게이트웨이/과부하 응답)
+func retryable(res backendResult) bool {
+	if res.err != nil {
+		return !errors.Is(res.err, context.DeadlineExceeded) && !errors.Is(res.err, context.Canceled)
+	}
+	switch res.status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// attempt 번째 재시도 전 대기 시간 (full jitter: 0 ~ min(max_backoff, backoff*2^attempt))
+func retryBackoff(cfg *RetryConfig, attempt int) time.Duration {
+	base, _ := time.ParseDuration(cfg.Backoff)
+	maxBackoff, _ := time.ParseDuration(cfg.MaxBackoff)
+	d := base << attempt
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	return time.Duration(rand.Int64N(int64(d))) + 1
+}
+
+// 백엔드 호출 결과
+type backendResult struct {
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+func (rt *route) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(r.Context(), rt.timeout)
+	defer cancel()
+
+	// 본문은 백엔드마다 다시 보내야 하므로 미리 읽어 둠
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			http.Error(w, "", http.StatusBadRequest)
+			return
+		}
+	}
+
+	e := rt.endpoint
+	results := make([]backendResult, len(e.Backend))
+	var wg sync.WaitGroup
+	for i := range e.Backend {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = rt.call(ctx, r, i, body)
+		}(i)
+	}
+	wg.Wait()
+
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+	var status int
+	var completed bool
+	if e.OutputEncoding == "no-op" {
+		status, completed = rt.writeNoOp(w, results[0])
+	} else {
+		status, completed = rt.writeMerged(w, results)
+	}
+	rt.proxy.metrics.recordEndpoint(e.Method+" "+e.Endpoint, status, time.Since(start), timedOut, !completed && status < 400)
+}
+
+// 백엔드 하나를 호출합니다 (서킷 브레이커와 재시도 적용)
+func (rt *route) call(ctx context.Context, r *http.Request, i int, body []byte) backendResult {
+	b := rt.endpoint.Backend[i]
+	cb := rt.breakers[i]
+	var retry *RetryConfig
+	if rc, ok := rt.proxy.retries[b.Host[0]]; ok && idempotent(b.Method) {
+		retry = &rc
+	}
+
+	name := backendName(b)
+	for attempt := 0; ; attempt++ {
+		var generation uint64
+		if cb != nil {
+			var ok bool
+			if generation, ok = cb.allow(); !ok {
+				rt.proxy.metrics.recordRejected(name)
+				return backendResult{err: errCircuitOpen}
+			}
+		}
+		res := rt.send(ctx, r, b, body)
+		failed := res.err != nil || res.status >= 500
+		if cb != nil {
+			cb.record(generation, !failed)
+		}
+		rt.proxy.metrics.recordBackend(name, failed, errors.Is(ctx.Err(), context.DeadlineExceeded))
+
+		if retry == nil || attempt >= retry.MaxRetries || !retryable(res) {
+			return res
+		}
+		rt.proxy.metrics.recordRetry(name)
+		select {
+		case <-time.After(retryBackoff(retry, attempt)):
+		case <-ctx.Done():
+			return res
+		}
+	}
+}
+
+// 백엔드에 요청을 한 번 보냅니다
+func (rt *route) send(ctx context.Context, r *http.Request, b Backend, body []byte) backendResult {
+	host := b.Host[0]
+	if h, ok := rt.proxy.hosts[host]; ok {
+		host = h
+	}
+
+	path := b.URLPattern
+	for _, name := range Placeholders(rt.endpoint.Endpoint) {
+		path = strings.ReplaceAll(path, "{"+name+"}", r.PathValue(name))
+	}
+	// 설정에 없는 쿼리 파라미터와 헤더는 백엔드로 전달하지 않음
+	query := r.URL.Query()
+	for k := range query {
+		if !contains(rt.endpoint.InputQueryStrings, k) {
+			query.Del(k)
+		}
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if len(body) > 0 {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, b.Method, host+path, reqBody)
+	if err != nil {
+		return backendResult{err: err}
+	}
+	for _, h := range rt.endpoint.InputHeaders {
+		if v := r.Header.Values(h); len(v) > 0 {
+			req.Header[http.CanonicalHeaderKey(h)] = v
+		}
+	}
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "identity") // Transport 가 압축을 임의로 풀지 않도록 함
+	}
+
+	res := backendResult{}
+	resp, err := rt.proxy.client.Do(req)
+	if err == nil {
+		res.status, res.header = resp.StatusCode, resp.Header
+		res.body, err = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	res.err = err
+	return res
+}
+
+// no-op: 백엔드 응답을 그대로 전달합니다
+func (rt *route) writeNoOp(w http.ResponseWriter, res backendResult) (int, bool) {
+	if res.err != nil {
+		w.Header().Set("X-KrakenD-Completed", "false")
+		w.WriteHeader(http.StatusInternalServerError)
+		return http.StatusInternalServerError, false
+	}
+	for k, v := range res.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(res.status)
+	w.Write(res.body)
+	return res.status, true
+}
+
+// 백엔드 JSON 응답을 병합해 반환합니다
+func (rt *route) writeMerged(w http.ResponseWriter, results []backendResult) (int, bool) {
+	backends := rt.endpoint.Backend
+
+	// 단일 백엔드의 에러 상태 코드 전달
+	if len(results) == 1 && results[0].err == nil && results[0].status >= 400 &&
+		backends[0].ExtraConfig != nil && backends[0].ExtraConfig.HTTP != nil && backends[0].ExtraConfig.HTTP.ReturnErrorCode {
+		if ct := results[0].header.Get("Content-Type"); ct != "" {
+			w.Header().Set("Content-Type", ct)
+		}
+		w.WriteHeader(results[0].status)
+		w.Write(results[0].body)
+		return results[0].status, false
+	}
+
+	merged := make(map[string]interface{})
+	succeeded := 0
+	for i, res := range results {
+		if res.err != nil || res.status < 200 || res.status >= 300 {
+			continue
+		}
+		data, err := decodeBackend(res.body)
+		if err != nil {
+			continue
+		}
+		succeeded++
+		applyBackendRules(merged, backends[i], data)
+	}
+
+	completed := succeeded == len(results)
+	w.Header().Set("X-KrakenD-Completed", fmt.Sprint(completed))
+	if succeeded == 0 {
+		w.WriteHeader(http.StatusInternalServerError)
+		return http.StatusInternalServerError, false
+	}
+	// negotiate 도 JSON 으로 응답 (이 저장소의 클라이언트는 JSON 만 사용)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(merged)
+	return http.StatusOK, completed
+}
+
+// 백엔드 응답을 객체로 디코딩합니다 (배열이면 KrakenD 와 같이 "collection" 필드에 담음)
+func decodeBackend(body []byte) (map[string]interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, nil
+	}
+	return map[string]interface{}{"collection": v}, nil
+}
+
+// allow → mapping → group 순서로 적용해 merged 에 합칩니다
+func applyBackendRules(merged map[string]interface{}, b Backend, data map[string]interface{}) {
+	if len(b.Allow) > 0 {
+		allowed := make(map[string]interface{}, len(b.Allow))
+		for _, k := range b.Allow {
+			if v, ok := data[k]; ok {
+				allowed[k] = v
+			}
+		}
+		data = allowed
+	}
+	for from, to := range b.Mapping {
+		if v, ok := data[from]; ok {
+			delete(data, from)
+			data[to] = v
+		}
+	}
+	if b.Group != "" {
+		merged[b.Group] = data
+		return
+	}
+	for k, v := range data {
+		merged[k] = v
+	}
+}
@@ -0,0 +1,143 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 테스트 함수 - allow → mapping → group 병합과 일부 백엔드 실패 시 X-KrakenD-Completed 확인
+func TestProxyMerge(t *testing.T) {
+	users := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": r.URL.Path[len("/users/"):], "name": "Alice", "createdAt": "2025-01-01"})
+	}))
+	defer users.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close() // 연결 실패하는 백엔드
+
+	cfg := validConfig()
+	cfg.Endpoints[0].Endpoint = "/api/overview/{id}"
+	cfg.Endpoints[0].Backend[0].Group = "user"
+	cfg.Endpoints[0].Backend[0].Allow = []string{"id", "name"}
+	cfg.Endpoints[0].Backend[0].Mapping = map[string]string{"name": "fullName"}
+	cfg.Endpoints[0].Backend = append(cfg.Endpoints[0].Backend, Backend{
+		Host:       []string{"http://localhost:8081"},
+		URLPattern: "/stocks",
+		Method:     "GET",
+	})
+
+	proxy, err := NewProxy(cfg, map[string]string{"http://localhost:3001": users.URL, "http://localhost:8081": down.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gw := httptest.NewServer(proxy)
+	defer gw.Close()
+
+	resp, err := http.Get(gw.URL + "/api/overview/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body map[string]map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-KrakenD-Completed") != "false" {
+		t.Errorf("status %d, X-KrakenD-Completed %q", resp.StatusCode, resp.Header.Get("X-KrakenD-Completed"))
+	}
+	user := body["user"]
+	if len(user) != 2 || user["id"] != "7" || user["fullName"] != "Alice" {
+		t.Errorf("user = %v", user)
+	}
+	if b := proxy.Metrics().Snapshot().Backends["GET http://localhost:8081/stocks"]; b.Errors != 1 {
+		t.Errorf("실패한 백엔드 지표 = %+v", b)
+	}
+}
+
+// 테스트 함수 - 멱등 요청만 재시도하고, 연속 실패 후에는 서킷이 열려 백엔드를 호출하지 않는지 확인
+func TestProxyRetryAndBreaker(t *testing.T) {
+	var calls, failUntil atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failUntil.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer backend.Close()
+
+	cfg := validConfig()
+	cfg.Endpoints[0].OutputEncoding = "no-op"
+	b := &cfg.Endpoints[0].Backend[0]
+	b.Encoding = "no-op"
+	b.ExtraConfig = &BackendExtraConfig{CircuitBreaker: &CircuitBreakerConfig{Interval: 60, Timeout: 10, MaxErrors: 3}}
+	post := cfg.Endpoints[0]
+	post.Method, post.Backend = "POST", []Backend{*b}
+	post.Backend[0].Method = "POST"
+	cfg.Endpoints = append(cfg.Endpoints, post)
+
+	proxy, err := NewProxy(cfg, map[string]string{"http://localhost:3001": backend.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy.SetRetries(map[string]RetryConfig{"http://localhost:3001": {MaxRetries: 2, Backoff: "1ms", MaxBackoff: "5ms"}})
+	now := time.Unix(1_700_000_000, 0)
+	proxy.now = func() time.Time { return now }
+	gw := httptest.NewServer(proxy)
+	defer gw.Close()
+
+	send := func(method string) int {
+		req, _ := http.NewRequest(method, gw.URL+"/api/users/1", nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// GET: 503 두 번 뒤 성공 → 재시도로 200
+	failUntil.Store(2)
+	if status := send(http.MethodGet); status != http.StatusOK || calls.Load() != 3 {
+		t.Fatalf("GET: status %d, calls %d", status, calls.Load())
+	}
+
+	// POST: 재시도하지 않음
+	calls.Store(0)
+	failUntil.Store(1)
+	if status := send(http.MethodPost); status != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Fatalf("POST: status %d, calls %d", status, calls.Load())
+	}
+
+	// 계속 실패하면 연속 에러가 max_errors(3) 를 넘어 서킷이 열림
+	calls.Store(0)
+	failUntil.Store(100)
+	send(http.MethodGet) // 3번 호출 (재시도 2번), 연속 에러 3번
+	// 연속 에러 4번째에 서킷이 열리고, 이어지는 재시도는 호출 없이 실패
+	if status := send(http.MethodGet); status != http.StatusInternalServerError {
+		t.Fatalf("서킷 열림: status %d, want 500", status)
+	}
+	stats := proxy.Metrics().Snapshot().Backends["GET http://localhost:3001/users/{id}"]
+	if stats.CircuitState != "open" || stats.CircuitOpened != 1 || stats.Rejected != 1 || stats.Retries != 5 {
+		t.Fatalf("지표 = %+v", stats)
+	}
+	called := calls.Load()
+	send(http.MethodGet)
+	if calls.Load() != called {
+		t.Error("서킷이 열렸는데 백엔드를 호출함")
+	}
+
+	// timeout 후 확인 요청이 성공하면 닫힘
+	failUntil.Store(0)
+	now = now.Add(10 * time.Second)
+	if status := send(http.MethodGet); status != http.StatusOK {
+		t.Fatalf("half-open 확인: status %d", status)
+	}
+	if s := proxy.Metrics().Snapshot().Backends["GET http://localhost:3001/users/{id}"]; s.CircuitState != "closed" {
+		t.Errorf("circuitState = %q, want closed", s.CircuitState)
+	}
+}
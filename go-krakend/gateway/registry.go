@@ -0,0 +1,165 @@
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Registry 는 krakend.json 을 생성하는 서비스 레지스트리(services.yaml)입니다
+type Registry struct {
+	Name     string             `yaml:"name"`
+	Port     int                `yaml:"port"`
+	Timeout  string             `yaml:"timeout"`
+	Metrics  *MetricsConfig     `yaml:"metrics"`
+	Logging  *LoggingConfig     `yaml:"logging"`
+	Services map[string]Service `yaml:"services"`
+	Routes   []Route            `yaml:"routes"`
+}
+
+// Service 는 이름으로 참조하는 백엔드 서비스입니다
+// 서킷 브레이커와 재시도 설정은 서비스의 모든 백엔드에 적용됩니다 (재시도는 Proxy 에서만)
+type Service struct {
+	Hosts          []string              `yaml:"hosts"`
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker"`
+	Retry          *RetryConfig          `yaml:"retry"`
+	HTTPCache      *HTTPCacheConfig      `yaml:"http_cache"` // GET 백엔드에만 적용
+}
+
+// Route 는 Gateway 엔드포인트 하나입니다
+type Route struct {
+	Endpoint       string         `yaml:"endpoint"`
+	Method         string         `yaml:"method"` // 기본값 GET
+	OutputEncoding string         `yaml:"output_encoding"`
+	QueryStrings   []string       `yaml:"query_strings"`
+	Headers        []string       `yaml:"headers"`
+	Timeout        string         `yaml:"timeout"`
+	Backends       []RouteBackend `yaml:"backends"`
+}
+
+// RouteBackend 는 엔드포인트가 호출하는 서비스와 경로입니다
+type RouteBackend struct {
+	Service         string            `yaml:"service"`
+	URLPattern      string            `yaml:"url_pattern"`
+	Method          string            `yaml:"method"`   // 기본값은 Route 의 method
+	Encoding        string            `yaml:"encoding"` // 기본값은 no-op 엔드포인트면 no-op
+	Group           string            `yaml:"group"`
+	Allow           []string          `yaml:"allow"`
+	Mapping         map[string]string `yaml:"mapping"`
+	ReturnErrorCode bool              `yaml:"return_error_code"`
+}
+
+// 서비스 레지스트리 파일을 읽습니다
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Registry
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true) // 오타난 항목이 조용히 무시되지 않도록 함
+	if err := dec.Decode(&r); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// 레지스트리로부터 krakend.json 설정을 생성합니다
+func (r *Registry) Generate() (*Config, error) {
+	cfg := &Config{
+		Version:   3,
+		Name:      r.Name,
+		Port:      r.Port,
+		Timeout:   r.Timeout,
+		Endpoints: make([]Endpoint, 0, len(r.Routes)),
+	}
+	if r.Metrics != nil || r.Logging != nil {
+		cfg.ExtraConfig = &ServiceExtraConfig{Metrics: r.Metrics, Logging: r.Logging}
+	}
+
+	for _, route := range r.Routes {
+		e := Endpoint{
+			Endpoint:          route.Endpoint,
+			Method:            route.Method,
+			OutputEncoding:    route.OutputEncoding,
+			InputQueryStrings: route.QueryStrings,
+			InputHeaders:      route.Headers,
+			Timeout:           route.Timeout,
+		}
+		if e.Method == "" {
+			e.Method = "GET"
+		}
+
+		for _, rb := range route.Backends {
+			svc, ok := r.Services[rb.Service]
+			if !ok {
+				return nil, fmt.Errorf("%s %s: 등록되지 않은 서비스 %q", e.Method, e.Endpoint, rb.Service)
+			}
+			b := Backend{
+				Host:       svc.Hosts,
+				URLPattern: rb.URLPattern,
+				Method:     rb.Method,
+				Encoding:   rb.Encoding,
+				Group:      rb.Group,
+				Allow:      rb.Allow,
+				Mapping:    rb.Mapping,
+			}
+			if b.Method == "" {
+				b.Method = e.Method
+			}
+			if b.Encoding == "" && e.OutputEncoding == "no-op" {
+				b.Encoding = "no-op"
+			}
+			if rb.ReturnErrorCode || svc.CircuitBreaker != nil || svc.HTTPCache != nil {
+				b.ExtraConfig = &BackendExtraConfig{}
+				if b.Method == "GET" {
+					b.ExtraConfig.HTTPCache = svc.HTTPCache
+				}
+				if rb.ReturnErrorCode {
+					b.ExtraConfig.HTTP = &BackendHTTPConfig{ReturnErrorCode: true}
+				}
+				if svc.CircuitBreaker != nil {
+					// 브레이커 이름은 로그/지표에서 구분할 수 있도록 서비스, 메서드, 경로로 지정
+					cb := *svc.CircuitBreaker
+					if cb.Name == "" {
+						cb.Name = rb.Service + " " + b.Method + " " + b.URLPattern
+					}
+					b.ExtraConfig.CircuitBreaker = &cb
+				}
+			}
+			e.Backend = append(e.Backend, b)
+		}
+		cfg.Endpoints = append(cfg.Endpoints, e)
+	}
+	return cfg, nil
+}
+
+// Retries 는 서비스의 retry 설정을 host 별로 반환합니다 (Proxy.SetRetries 에 전달)
+// KrakenD CE 에는 백엔드 재시도 기능이 없어 krakend.json 에는 쓰지 않습니다
+func (r *Registry) Retries() (map[string]RetryConfig, error) {
+	names := make([]string, 0, len(r.Services))
+	for name := range r.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs ValidationError
+	retries := make(map[string]RetryConfig)
+	for _, name := range names {
+		svc := r.Services[name]
+		if svc.Retry == nil {
+			continue
+		}
+		errs = append(errs, svc.Retry.problems(name)...)
+		for _, h := range svc.Hosts {
+			retries[h] = *svc.Retry
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return retries, nil
+}
@@ -0,0 +1,232 @@
+package gateway
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 이 저장소에서 사용하는 KrakenD 인코딩
+var (
+	outputEncodings  = []string{"json", "json-collection", "fast-json", "xml", "negotiate", "string", "no-op"}
+	backendEncodings = []string{"json", "safejson", "xml", "rss", "string", "no-op"}
+	httpMethods      = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+)
+
+// 경로의 {param} 자리표시자
+var placeholderPattern = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// ValidationError 는 검증에서 발견한 문제 목록입니다
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "krakend 설정 검증 실패:\n  - " + strings.Join(e, "\n  - ")
+}
+
+// Placeholders 는 경로에 포함된 자리표시자 이름 목록을 반환합니다
+func Placeholders(path string) []string {
+	var names []string
+	for _, m := range placeholderPattern.FindAllStringSubmatch(path, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// 양수인 duration 문자열인지 확인합니다
+func positiveDuration(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("0보다 커야 합니다")
+	}
+	return nil
+}
+
+// 백엔드 host 가 경로 없는 http(s) URL 인지 확인합니다
+func validHost(host string) error {
+	u, err := url.Parse(host)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("http 또는 https URL 이어야 합니다")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("호스트가 없습니다")
+	}
+	if u.Path != "" && u.Path != "/" {
+		return fmt.Errorf("경로는 url_pattern 에 지정해야 합니다")
+	}
+	return nil
+}
+
+// 설정을 검증하고, 문제가 있으면 모든 문제를 담은 ValidationError 를 반환합니다
+func (c *Config) Validate() error {
+	var errs ValidationError
+	addf := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if c.Version != 3 {
+		addf("version: 3 이어야 합니다 (현재 %d)", c.Version)
+	}
+	if c.Name == "" {
+		addf("name: 비어 있습니다")
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		addf("port: 1~65535 범위가 아닙니다 (%d)", c.Port)
+	}
+	if err := positiveDuration(c.Timeout); err != nil {
+		addf("timeout %q: %v", c.Timeout, err)
+	}
+	if c.ExtraConfig != nil && c.ExtraConfig.Metrics != nil {
+		m := c.ExtraConfig.Metrics
+		if err := positiveDuration(m.CollectionTime); err != nil {
+			addf("telemetry/metrics collection_time %q: %v", m.CollectionTime, err)
+		}
+		if _, _, err := net.SplitHostPort(m.ListenAddress); err != nil {
+			addf("telemetry/metrics listen_address %q: %v", m.ListenAddress, err)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range c.Endpoints {
+		name := e.Method + " " + e.Endpoint
+		if seen[name] {
+			addf("%s: 중복된 엔드포인트입니다", name)
+		}
+		seen[name] = true
+
+		if !strings.HasPrefix(e.Endpoint, "/") {
+			addf("%s: endpoint 는 / 로 시작해야 합니다", name)
+		}
+		if !contains(httpMethods, e.Method) {
+			addf("%s: 지원하지 않는 method 입니다", name)
+		}
+		if e.OutputEncoding != "" && !contains(outputEncodings, e.OutputEncoding) {
+			addf("%s: 알 수 없는 output_encoding %q", name, e.OutputEncoding)
+		}
+		if e.Timeout != "" {
+			if err := positiveDuration(e.Timeout); err != nil {
+				addf("%s: timeout %q: %v", name, e.Timeout, err)
+			}
+		}
+		if len(e.Backend) == 0 {
+			addf("%s: backend 가 없습니다", name)
+		}
+		if e.OutputEncoding == "no-op" && len(e.Backend) != 1 {
+			addf("%s: no-op 엔드포인트는 backend 가 하나여야 합니다", name)
+		}
+
+		params := Placeholders(e.Endpoint)
+		for i, b := range e.Backend {
+			bname := fmt.Sprintf("%s backend[%d]", name, i)
+			if len(b.Host) == 0 {
+				addf("%s: host 가 없습니다", bname)
+			}
+			for _, h := range b.Host {
+				if err := validHost(h); err != nil {
+					addf("%s: host %q: %v", bname, h, err)
+				}
+			}
+			if !strings.HasPrefix(b.URLPattern, "/") {
+				addf("%s: url_pattern 은 / 로 시작해야 합니다", bname)
+			}
+			for _, p := range Placeholders(b.URLPattern) {
+				if !contains(params, p) {
+					addf("%s: url_pattern 의 {%s} 가 endpoint %s 에 없습니다", bname, p, e.Endpoint)
+				}
+			}
+			if !contains(httpMethods, b.Method) {
+				addf("%s: 지원하지 않는 method %q", bname, b.Method)
+			}
+			if b.Encoding != "" && !contains(backendEncodings, b.Encoding) {
+				addf("%s: 알 수 없는 encoding %q", bname, b.Encoding)
+			}
+			if e.OutputEncoding == "no-op" && b.Encoding != "no-op" {
+				addf("%s: no-op 엔드포인트의 backend 는 encoding 도 no-op 이어야 합니다", bname)
+			}
+			if b.ExtraConfig == nil {
+				continue
+			}
+			if cb := b.ExtraConfig.CircuitBreaker; cb != nil {
+				if cb.Interval <= 0 || cb.Timeout <= 0 || cb.MaxErrors <= 0 {
+					addf("%s: qos/circuit-breaker 의 interval, timeout, max_errors 는 0보다 커야 합니다", bname)
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// 모든 백엔드 host 에 TCP 연결이 되는지 확인합니다 (로컬에서 백엔드를 띄운 상태로 사용)
+func (c *Config) CheckReachable(timeout time.Duration) error {
+	hosts := make(map[string]bool)
+	for _, e := range c.Endpoints {
+		for _, b := range e.Backend {
+			for _, h := range b.Host {
+				hosts[h] = true
+			}
+		}
+	}
+	sorted := make([]string, 0, len(hosts))
+	for h := range hosts {
+		sorted = append(sorted, h)
+	}
+	sort.Strings(sorted)
+
+	var errs ValidationError
+	for _, h := range sorted {
+		u, err := url.Parse(h)
+		if err != nil {
+			continue // Validate 에서 보고
+		}
+		addr := u.Host
+		if u.Port() == "" {
+			addr = net.JoinHostPort(u.Hostname(), map[string]string{"http": "80", "https": "443"}[u.Scheme])
+		}
+		conn, err := net.DialTimeout("tcp", addr, timeout)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("host %s: 연결할 수 없습니다: %v", h, err))
+			continue
+		}
+		conn.Close()
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// 재시도 설정의 문제 목록을 반환합니다
+func (rt RetryConfig) problems(name string) []string {
+	var errs []string
+	if rt.MaxRetries < 0 {
+		errs = append(errs, fmt.Sprintf("%s: retry max_retries 는 0 이상이어야 합니다", name))
+	}
+	for _, d := range []string{rt.Backoff, rt.MaxBackoff} {
+		if err := positiveDuration(d); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: retry backoff %q: %v", name, d, err))
+		}
+	}
+	return errs
+}
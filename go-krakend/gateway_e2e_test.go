@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yiminan/go-examples/go-krakend/gateway"
+)
+
+// krakend.json 을 해석하는 Proxy 를 띄웁니다 (재시도는 services.yaml 의 설정 적용)
+// 설정의 사용자 서비스 host(:3001)는 usersURL 로 바꿔 호출합니다
+func newTestGateway(t *testing.T, usersURL string) (*httptest.Server, *gateway.Proxy, *gateway.Config) {
+	t.Helper()
+	cfg, err := gateway.Load("krakend.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy, err := gateway.NewProxy(cfg, map[string]string{"http://localhost:3001": usersURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy.SetRetries(testRetries(t))
+	gw := httptest.NewServer(proxy)
+	t.Cleanup(gw.Close)
+	return gw, proxy, cfg
+}
+
+// services.yaml 의 host 별 재시도 설정
+func testRetries(t *testing.T) map[string]gateway.RetryConfig {
+	t.Helper()
+	registry, err := gateway.LoadRegistry("services.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	retries, err := registry.Retries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return retries
+}
+
+// 요청을 보내고 응답과 본문을 반환합니다
+func send(t *testing.T, method, url, body string, header map[string]string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(data)
+}
+
+// 테스트 함수 - Gateway 를 거친 사용자 API 의 상태 코드와 헤더 전달 확인
+func TestGatewayUsersRouting(t *testing.T) {
+	srv := newTestServer(t)
+	if err := seedUsers(); err != nil {
+		t.Fatal(err)
+	}
+	gw, _, _ := newTestGateway(t, srv.URL)
+	jsonHeader := map[string]string{"Content-Type": "application/json"}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		header map[string]string
+		status int
+	}{
+		{"list", http.MethodGet, "/api/users", "", nil, http.StatusOK},
+		{"list with allowed query", http.MethodGet, "/api/users?offset=0&limit=1&debug=1", "", nil, http.StatusOK},
+		{"list error code passthrough", http.MethodGet, "/api/users?limit=0", "", nil, http.StatusBadRequest},
+		{"create", http.MethodPost, "/api/users", `{"name":"Bob","email":"bob@example.com"}`, jsonHeader, http.StatusCreated},
+		{"create invalid", http.MethodPost, "/api/users", `{"name":"","email":"bob"}`, jsonHeader, http.StatusBadRequest},
+		{"get", http.MethodGet, "/api/users/1", "", nil, http.StatusOK},
+		{"get missing", http.MethodGet, "/api/users/999", "", nil, http.StatusNotFound},
+		{"get invalid id", http.MethodGet, "/api/users/abc", "", nil, http.StatusBadRequest},
+		{"update", http.MethodPut, "/api/users/2", `{"name":"Bobby","email":"bob@example.com"}`, jsonHeader, http.StatusOK},
+		{"delete", http.MethodDelete, "/api/users/2", "", nil, http.StatusNoContent},
+		{"get deleted", http.MethodGet, "/api/users/2", "", nil, http.StatusNotFound},
+		{"unknown endpoint", http.MethodGet, "/api/orders", "", nil, http.StatusNotFound},
+		{"method not in config", http.MethodPatch, "/api/users/1", "{}", jsonHeader, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		resp, body := send(t, tt.method, gw.URL+tt.path, tt.body, tt.header)
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: %s %s status %d, want %d (body %q)", tt.name, tt.method, tt.path, resp.StatusCode, tt.status, body)
+		}
+	}
+
+	// 병합(negotiate) 엔드포인트: input_query_strings 의 limit 만 전달됨
+	resp, body := send(t, http.MethodGet, gw.URL+"/api/users?limit=1", "", nil)
+	var list userList
+	if err := json.Unmarshal([]byte(body), &list); err != nil || list.Limit != 1 || list.Total != 1 {
+		t.Errorf("목록 = %+v, err %v", list, err)
+	}
+	if resp.Header.Get("X-KrakenD-Completed") != "true" {
+		t.Errorf("X-KrakenD-Completed = %q", resp.Header.Get("X-KrakenD-Completed"))
+	}
+
+	// no-op 엔드포인트: input_headers 의 If-None-Match 가 전달되고 응답 헤더도 그대로 반환
+	resp, _ = send(t, http.MethodGet, gw.URL+"/api/users/1", "", nil)
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag 가 전달되지 않음")
+	}
+	resp, _ = send(t, http.MethodGet, gw.URL+"/api/users/1", "", map[string]string{"If-None-Match": etag})
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("If-None-Match: status %d, want 304", resp.StatusCode)
+	}
+}
+
+// 테스트 함수 - 백엔드가 응답하지 않으면 설정의 timeout(3000ms) 후 500 을 반환하는지 확인
+func TestGatewayTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	// 요청이 취소될 때까지 응답하지 않는 백엔드
+	hang := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer hang.Close()
+
+	gw, proxy, cfg := newTestGateway(t, hang.URL)
+	if cfg.Timeout != "3000ms" {
+		t.Fatalf("timeout = %q, want 3000ms", cfg.Timeout)
+	}
+
+	for _, path := range []string{"/api/users/1", "/api/users"} {
+		start := time.Now()
+		resp, _ := send(t, http.MethodGet, gw.URL+path, "", nil)
+		elapsed := time.Since(start)
+		if resp.StatusCode != http.StatusInternalServerError {
+			t.Errorf("%s: status %d, want 500", path, resp.StatusCode)
+		}
+		if elapsed < 3*time.Second || elapsed > 4*time.Second {
+			t.Errorf("%s: %v 만에 응답, want 약 3s", path, elapsed)
+		}
+	}
+
+	stats := proxy.Metrics().Snapshot()
+	if s := stats.Endpoints["GET /api/users/{id}"]; s.Timeouts != 1 {
+		t.Errorf("타임아웃 지표 = %+v", s)
+	}
+}
+
+// 테스트 함수 - telemetry/metrics 의 listen_address 에서 /__stats 로 지표가 노출되는지 확인
+func TestGatewayMetrics(t *testing.T) {
+	srv := newTestServer(t)
+	if err := seedUsers(); err != nil {
+		t.Fatal(err)
+	}
+	gw, proxy, _ := newTestGateway(t, srv.URL)
+
+	addr := proxy.MetricsAddress()
+	if addr != ":8090" {
+		t.Fatalf("listen_address = %q, want :8090", addr)
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		// 다른 프로세스가 사용 중이면 임시 포트로 대신 확인
+		t.Logf("%s 사용 불가 (%v), 임시 포트 사용", addr, err)
+		if ln, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	metricsSrv := &http.Server{Handler: proxy.Metrics()}
+	go metricsSrv.Serve(ln)
+	defer metricsSrv.Close()
+
+	send(t, http.MethodGet, gw.URL+"/api/users/1", "", nil)
+	send(t, http.MethodGet, gw.URL+"/api/users/1", "", nil)
+	send(t, http.MethodGet, gw.URL+"/api/users/999", "", nil)
+
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+	resp, body := send(t, http.MethodGet, "http://127.0.0.1:"+port+"/__stats", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("/__stats status %d", resp.StatusCode)
+	}
+	var stats gateway.MetricsSnapshot
+	if err := json.Unmarshal([]byte(body), &stats); err != nil {
+		t.Fatalf("지표 디코딩 실패: %v (%s)", err, body)
+	}
+	e := stats.Endpoints["GET /api/users/{id}"]
+	if e.Requests != 3 || e.Statuses["200"] != 2 || e.Statuses["404"] != 1 {
+		t.Errorf("엔드포인트 지표 = %+v", e)
+	}
+	if b := stats.Backends["GET http://localhost:3001/users/{id}"]; b.Requests != 3 || b.Errors != 0 {
+		t.Errorf("백엔드 지표 = %+v", b)
+	}
+}
+
+// 테스트 함수 - 사용자 서비스가 내려가면 서킷이 열려 이후 요청은 백엔드를 기다리지 않고 바로 실패하는지 확인
+func TestGatewayCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	gw, proxy, cfg := newTestGateway(t, down.URL)
+
+	e, _ := cfg.FindEndpoint(http.MethodGet, "/api/users/{id}")
+	cb := e.Backend[0].ExtraConfig.CircuitBreaker
+	retry, ok := testRetries(t)["http://localhost:3001"]
+	if cb == nil || !ok {
+		t.Fatal("서킷 브레이커(krakend.json) 또는 재시도(services.yaml) 설정이 없음")
+	}
+
+	// 연속 에러가 max_errors 를 넘을 때까지 요청 (요청마다 재시도 포함)
+	for i := 0; i*(retry.MaxRetries+1) <= cb.MaxErrors; i++ {
+		if resp, _ := send(t, http.MethodGet, gw.URL+"/api/users/1", "", nil); resp.StatusCode != http.StatusServiceUnavailable && resp.StatusCode != http.StatusInternalServerError {
+			t.Fatalf("status %d", resp.StatusCode)
+		}
+	}
+	stats := proxy.Metrics().Snapshot().Backends["GET http://localhost:3001/users/{id}"]
+	if stats.CircuitState != "open" || stats.CircuitOpened != 1 {
+		t.Fatalf("백엔드 지표 = %+v", stats)
+	}
+
+	before := calls.Load()
+	start := time.Now()
+	resp, _ := send(t, http.MethodGet, gw.URL+"/api/users/1", "", nil)
+	if resp.StatusCode != http.StatusInternalServerError || time.Since(start) > 100*time.Millisecond {
+		t.Errorf("서킷 열림: status %d, %v 소요", resp.StatusCode, time.Since(start))
+	}
+	if calls.Load() != before {
+		t.Error("서킷이 열렸는데 백엔드를 호출함")
+	}
+}
@@ -1,19 +1,95 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"flag"
+	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/yiminan/go-examples/go-ratelimit"
 )
 
-func usersHandler(w http.ResponseWriter, r *http.Request) {
-	// 객체(object) 형태로 반환
-	response := map[string]interface{}{
-		"users": []map[string]string{{"id": "1", "name": "Alice"}},
+var db *badger.DB
+
+// 사용자 서비스의 레이트 리밋 (nil 이면 제한 없음)
+var limiter *ratelimit.Limiter
+
+// 한도를 넘은 요청의 429 응답 (요청한 형식의 에러 본문)
+func rejectRateLimited(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, http.StatusTooManyRequests, "Too many requests")
+}
+
+// BadgerDB 를 열고 사용자 ID 시퀀스를 준비합니다 (dir 이 비어 있으면 in-memory)
+func openDB(dir string) error {
+	opts := badger.DefaultOptions(dir).WithInMemory(dir == "")
+	opts.Logger = nil // 로깅 비활성화
+
+	var err error
+	if db, err = badger.Open(opts); err != nil {
+		return err
+	}
+	if userSeq, err = db.GetSequence([]byte(userSeqKey), 100); err != nil {
+		db.Close()
+		return err
 	}
-	json.NewEncoder(w).Encode(response)
+	return nil
+}
+
+// DB 를 닫습니다 (사용하지 않은 시퀀스 범위를 반환한 뒤 닫음)
+func closeDB() {
+	userSeq.Release()
+	db.Close()
+}
+
+// 사용자 서비스 라우트 등록
+func registerRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/users", limiter.Wrap("/users", requireAcceptable(usersHandler)))
+	mux.HandleFunc("/users/{id}", limiter.Wrap("/users/{id}", requireAcceptable(userHandler)))
 }
 
 func main() {
-	http.HandleFunc("/users", usersHandler)
-	http.ListenAndServe(":3001", nil)
+	addr := flag.String("addr", ":3001", "HTTP 서버 주소")
+	dataDir := flag.String("data", "data/users", "BadgerDB 데이터 디렉터리")
+	rate := flag.String("rate", "", "클라이언트(IP)별 기본 한도 rate:burst (예: 10:20, 비우면 제한 없음)")
+	routeLimits := flag.String("route-limits", "", "라우트별 클라이언트 한도 (예: /users=5:10,/users/{id}=20:40)")
+	clientLimits := flag.String("client-limits", "", "X-API-Key 별 한도 (예: partner-key=100:200)")
+	clusterRate := flag.Int("cluster-rate", 0, "라우트별 초당 전체 요청 수 (모든 클라이언트 합계, 이 프로세스 안에서만 적용, 0 이면 사용 안 함)")
+	flag.Parse()
+
+	var err error
+	limiter, err = ratelimit.FromFlags(*rate, *routeLimits, *clientLimits, *clusterRate, func() *badger.DB { return db }, rejectRateLimited)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := openDB(*dataDir); err != nil {
+		log.Fatal(err)
+	}
+	defer closeDB()
+
+	if err := seedUsers(); err != nil {
+		log.Fatal(err)
+	}
+
+	registerRoutes(http.DefaultServeMux)
+	server := &http.Server{Addr: *addr}
+
+	// 종료 시그널을 받으면 처리 중인 요청을 마치고 DB 를 닫음
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	fmt.Printf("🚀 Users Server started at http://localhost%s\n", *addr)
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatalf("HTTP server failed: %v", err)
+	}
 }
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+
+	"github.com/yiminan/go-examples/go-ratelimit"
+)
+
+// 전역 limiter 를 설정하고 고정된 시각을 사용하는 사용자 서비스를 띄웁니다
+func newRateLimitedServer(t *testing.T, l *ratelimit.Limiter) string {
+	t.Helper()
+	now := time.Unix(1_700_000_000, 0)
+	l.Now = func() time.Time { return now }
+	limiter = l
+	t.Cleanup(func() { limiter = nil })
+	srv := newTestServer(t)
+	if err := seedUsers(); err != nil {
+		t.Fatal(err)
+	}
+	return srv.URL
+}
+
+func TestUsersRateLimit(t *testing.T) {
+	url := newRateLimitedServer(t, ratelimit.New(
+		ratelimit.Limit{Rate: 1, Burst: 2},
+		map[string]ratelimit.Limit{"/users/{id}": {Rate: 1, Burst: 1}},
+		map[string]ratelimit.Limit{"partner": {Rate: 10, Burst: 5}},
+		0, func() *badger.DB { return db }, rejectRateLimited,
+	))
+
+	// 기본 한도: 버스트 2 이후 429
+	for i := 0; i < 2; i++ {
+		if resp, _ := get(t, url+"/users", nil); resp.StatusCode != http.StatusOK {
+			t.Fatalf("%d번째 요청: status %d", i+1, resp.StatusCode)
+		}
+	}
+	resp, body := get(t, url+"/users", nil)
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("status %d, want 429", resp.StatusCode)
+	}
+	if ra := resp.Header.Get("Retry-After"); ra != "1" {
+		t.Errorf("Retry-After = %q", ra)
+	}
+	if !strings.Contains(string(body), "Too many requests") {
+		t.Errorf("body = %s", body)
+	}
+
+	// 라우트별 한도는 다른 라우트와 따로 계산
+	if resp, _ := get(t, url+"/users/1", nil); resp.StatusCode != http.StatusOK {
+		t.Fatalf("/users/1: status %d", resp.StatusCode)
+	}
+	if resp, _ := get(t, url+"/users/1", nil); resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("/users/1 두 번째: status %d, want 429", resp.StatusCode)
+	}
+
+	// 등록된 API 키는 IP 와 별도의 한도, 등록되지 않은 키는 IP 한도
+	partner := map[string]string{"X-API-Key": "partner"}
+	for i := 0; i < 5; i++ {
+		if resp, _ := get(t, url+"/users", partner); resp.StatusCode != http.StatusOK {
+			t.Fatalf("partner %d번째 요청: status %d", i+1, resp.StatusCode)
+		}
+	}
+	if resp, _ := get(t, url+"/users", map[string]string{"X-API-Key": "unknown"}); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("등록되지 않은 키: status %d, want 429", resp.StatusCode)
+	}
+}
+
+func TestUsersClusterRateLimit(t *testing.T) {
+	url := newRateLimitedServer(t, ratelimit.New(ratelimit.Limit{}, nil, map[string]ratelimit.Limit{
+		"a": {Rate: 100, Burst: 100},
+		"b": {Rate: 100, Burst: 100},
+	}, 3, func() *badger.DB { return db }, rejectRateLimited))
+
+	// 클라이언트가 달라도 라우트 전체 한도(초당 3)를 함께 사용
+	statuses := make([]int, 0, 4)
+	for _, key := range []string{"a", "b", "a", "b"} {
+		resp, _ := get(t, url+"/users", map[string]string{"X-API-Key": key})
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if statuses[2] != http.StatusOK || statuses[3] != http.StatusTooManyRequests {
+		t.Fatalf("statuses = %v", statuses)
+	}
+
+	// 다른 라우트는 별도 카운터
+	if resp, _ := get(t, url+"/users/1", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("/users/1: status %d", resp.StatusCode)
+	}
+}
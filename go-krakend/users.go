@@ -0,0 +1,321 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+const (
+	userKeyPrefix = "user:"
+	userSeqKey    = "seq:user"
+
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+	maxNameLength    = 50
+)
+
+var errUserNotFound = errors.New("user not found")
+
+// User 는 사용자 서비스가 저장하는 사용자 정보입니다
+type User struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// userInput 은 생성/수정 요청 본문입니다
+type userInput struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// userList 는 목록 조회 응답입니다 (기존 응답과 같이 "users" 필드에 목록을 담음)
+type userList struct {
+	Users  []User `json:"users"`
+	Total  int    `json:"total"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+}
+
+// errorResponse 는 모든 에러 응답의 JSON 형식입니다
+type errorResponse struct {
+	Error  string            `json:"error"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// 사용자 ID 시퀀스 (BadgerDB Sequence 로 재시작 후에도 ID 가 겹치지 않음)
+var userSeq *badger.Sequence
+
+// 사용자 키 생성 (ID 를 0으로 채워 키 순서가 곧 생성 순서가 되도록 함)
+func userKey(id uint64) []byte {
+	return []byte(fmt.Sprintf("%s%010d", userKeyPrefix, id))
+}
+
+// 경로의 사용자 ID 를 파싱합니다
+func parseUserID(s string) (uint64, bool) {
+	id, err := strconv.ParseUint(s, 10, 64)
+	return id, err == nil && id > 0
+}
+
+// 생성/수정 요청을 검증하고, 문제가 있는 필드별 메시지를 반환합니다
+func (in *userInput) validate() map[string]string {
+	fields := make(map[string]string)
+
+	in.Name = strings.TrimSpace(in.Name)
+	switch {
+	case in.Name == "":
+		fields["name"] = "필수 항목입니다"
+	case utf8.RuneCountInString(in.Name) > maxNameLength:
+		fields["name"] = fmt.Sprintf("%d자 이하여야 합니다", maxNameLength)
+	}
+
+	in.Email = strings.TrimSpace(in.Email)
+	if in.Email == "" {
+		fields["email"] = "필수 항목입니다"
+	} else if addr, err := mail.ParseAddress(in.Email); err != nil || addr.Address != in.Email {
+		fields["email"] = "올바른 이메일 주소가 아닙니다"
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// 사용자를 저장합니다
+func saveUser(txn *badger.Txn, id uint64, u User) error {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	return txn.Set(userKey(id), data)
+}
+
+// 사용자를 조회합니다
+func loadUser(txn *badger.Txn, id uint64) (User, error) {
+	var u User
+	item, err := txn.Get(userKey(id))
+	if err == badger.ErrKeyNotFound {
+		return u, errUserNotFound
+	}
+	if err != nil {
+		return u, err
+	}
+	err = item.Value(func(val []byte) error {
+		return json.Unmarshal(val, &u)
+	})
+	return u, err
+}
+
+// 새 사용자를 생성합니다
+func createUser(in userInput) (User, error) {
+	seq, err := userSeq.Next()
+	if err != nil {
+		return User{}, err
+	}
+	id := seq + 1 // Sequence 는 0부터 시작하므로 ID 는 1부터 사용
+
+	now := time.Now().UTC()
+	u := User{
+		ID:        strconv.FormatUint(id, 10),
+		Name:      in.Name,
+		Email:     in.Email,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	err = db.Update(func(txn *badger.Txn) error {
+		return saveUser(txn, id, u)
+	})
+	return u, err
+}
+
+// 사용자 정보를 수정합니다
+func updateUser(id uint64, in userInput) (User, error) {
+	var u User
+	err := db.Update(func(txn *badger.Txn) error {
+		var err error
+		if u, err = loadUser(txn, id); err != nil {
+			return err
+		}
+		u.Name = in.Name
+		u.Email = in.Email
+		u.UpdatedAt = time.Now().UTC()
+		return saveUser(txn, id, u)
+	})
+	return u, err
+}
+
+// 사용자를 삭제합니다
+func deleteUser(id uint64) error {
+	return db.Update(func(txn *badger.Txn) error {
+		if _, err := txn.Get(userKey(id)); err == badger.ErrKeyNotFound {
+			return errUserNotFound
+		} else if err != nil {
+			return err
+		}
+		return txn.Delete(userKey(id))
+	})
+}
+
+// 생성 순서로 offset 부터 limit 명의 사용자와 전체 사용자 수를 조회합니다
+func listUsers(offset, limit int) ([]User, int, error) {
+	users := make([]User, 0, limit)
+	total := 0
+	err := db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false // 전체 수를 세는 동안 페이지 밖의 값은 읽지 않음
+		opts.Prefix = []byte(userKeyPrefix)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			total++
+			if total <= offset || len(users) >= limit {
+				continue
+			}
+			var u User
+			if err := it.Item().Value(func(val []byte) error {
+				return json.Unmarshal(val, &u)
+			}); err != nil {
+				return err
+			}
+			users = append(users, u)
+		}
+		return nil
+	})
+	return users, total, err
+}
+
+// DB 가 비어 있으면 기존 고정 응답에 있던 사용자를 넣어 둡니다
+func seedUsers() error {
+	_, total, err := listUsers(0, 1)
+	if err != nil || total > 0 {
+		return err
+	}
+	_, err = createUser(userInput{Name: "Alice", Email: "alice@example.com"})
+	return err
+}
+
+// 요청 본문을 읽고 검증합니다. 실패하면 에러 응답을 쓰고 false 를 반환합니다
+func decodeUserInput(w http.ResponseWriter, r *http.Request) (userInput, bool) {
+	var in userInput
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&in); err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return in, false
+	}
+	if fields := in.validate(); fields != nil {
+		writeResponse(w, r, http.StatusBadRequest, errorResponse{Error: "Validation failed", Fields: fields})
+		return in, false
+	}
+	return in, true
+}
+
+// 쿼리 파라미터의 음이 아닌 정수를 읽습니다 (없으면 def)
+func queryInt(r *http.Request, name string, def int) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return def, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("'%s' 는 0 이상의 정수여야 합니다", name)
+	}
+	return v, nil
+}
+
+// /users : 목록 조회(GET), 생성(POST)
+func usersHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		offset, err := queryInt(r, "offset", 0)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		limit, err := queryInt(r, "limit", defaultPageLimit)
+		if err != nil || limit == 0 || limit > maxPageLimit {
+			writeError(w, r, http.StatusBadRequest, fmt.Sprintf("'limit' 는 1~%d 사이여야 합니다", maxPageLimit))
+			return
+		}
+
+		users, total, err := listUsers(offset, limit)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Failed to list users")
+			return
+		}
+		writeResponse(w, r, http.StatusOK, userList{Users: users, Total: total, Offset: offset, Limit: limit})
+
+	case http.MethodPost:
+		in, ok := decodeUserInput(w, r)
+		if !ok {
+			return
+		}
+		u, err := createUser(in)
+		if err != nil {
+			writeError(w, r, http.StatusInternalServerError, "Failed to create user")
+			return
+		}
+		w.Header().Set("Location", "/users/"+u.ID)
+		writeResponse(w, r, http.StatusCreated, u)
+
+	default:
+		writeMethodNotAllowed(w, r, http.MethodGet, http.MethodHead, http.MethodPost)
+	}
+}
+
+// /users/{id} : 조회(GET), 수정(PUT), 삭제(DELETE)
+func userHandler(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseUserID(r.PathValue("id"))
+	if !ok {
+		writeError(w, r, http.StatusBadRequest, "Invalid user id")
+		return
+	}
+
+	var (
+		u   User
+		err error
+	)
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		err = db.View(func(txn *badger.Txn) error {
+			u, err = loadUser(txn, id)
+			return err
+		})
+	case http.MethodPut:
+		in, ok := decodeUserInput(w, r)
+		if !ok {
+			return
+		}
+		u, err = updateUser(id, in)
+	case http.MethodDelete:
+		if err = deleteUser(id); err == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	default:
+		writeMethodNotAllowed(w, r, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete)
+		return
+	}
+
+	switch {
+	case err == errUserNotFound:
+		writeError(w, r, http.StatusNotFound, "User not found")
+	case err != nil:
+		writeError(w, r, http.StatusInternalServerError, "Failed to access user")
+	default:
+		writeResponse(w, r, http.StatusOK, u)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// 테스트용 in-memory DB 와 사용자 서비스 서버를 띄웁니다
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	if err := openDB(""); err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	registerRoutes(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(func() {
+		srv.Close()
+		closeDB()
+	})
+	return srv
+}
+
+// 요청을 보내고 응답 본문을 v 로 디코딩합니다
+func doJSON(t *testing.T, method, url, body string, v interface{}) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("%s %s: 응답 디코딩 실패: %v", method, url, err)
+		}
+	}
+	return resp
+}
+
+func TestUsersCRUD(t *testing.T) {
+	srv := newTestServer(t)
+
+	// 생성
+	var created User
+	resp := doJSON(t, http.MethodPost, srv.URL+"/users", `{"name":"Bob","email":"bob@example.com"}`, &created)
+	if resp.StatusCode != http.StatusCreated || created.ID == "" || created.Name != "Bob" {
+		t.Fatalf("생성: status %d, user %+v", resp.StatusCode, created)
+	}
+	if loc := resp.Header.Get("Location"); loc != "/users/"+created.ID {
+		t.Errorf("Location = %q", loc)
+	}
+
+	// 조회
+	var got User
+	resp = doJSON(t, http.MethodGet, srv.URL+"/users/"+created.ID, "", &got)
+	if resp.StatusCode != http.StatusOK || got.Email != "bob@example.com" {
+		t.Fatalf("조회: status %d, user %+v", resp.StatusCode, got)
+	}
+
+	// 수정
+	var updated User
+	resp = doJSON(t, http.MethodPut, srv.URL+"/users/"+created.ID, `{"name":"Bobby","email":"bobby@example.com"}`, &updated)
+	if resp.StatusCode != http.StatusOK || updated.Name != "Bobby" || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Fatalf("수정: status %d, user %+v", resp.StatusCode, updated)
+	}
+
+	// 삭제 후 조회
+	resp = doJSON(t, http.MethodDelete, srv.URL+"/users/"+created.ID, "", nil)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("삭제: status %d", resp.StatusCode)
+	}
+	var errResp errorResponse
+	resp = doJSON(t, http.MethodGet, srv.URL+"/users/"+created.ID, "", &errResp)
+	if resp.StatusCode != http.StatusNotFound || errResp.Error == "" {
+		t.Fatalf("삭제 후 조회: status %d, body %+v", resp.StatusCode, errResp)
+	}
+}
+
+func TestUsersPagination(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		doJSON(t, http.MethodPost, srv.URL+"/users", `{"name":"`+name+`","email":"`+name+`@example.com"}`, nil)
+	}
+
+	var page userList
+	resp := doJSON(t, http.MethodGet, srv.URL+"/users?offset=1&limit=2", "", &page)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	if page.Total != 5 || len(page.Users) != 2 || page.Users[0].Name != "B" || page.Users[1].Name != "C" {
+		t.Errorf("page = %+v", page)
+	}
+
+	resp = doJSON(t, http.MethodGet, srv.URL+"/users?limit=0", "", nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("limit=0: status %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestUsersValidation(t *testing.T) {
+	srv := newTestServer(t)
+
+	var errResp errorResponse
+	resp := doJSON(t, http.MethodPost, srv.URL+"/users", `{"name":" ","email":"not-an-email"}`, &errResp)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if errResp.Fields["name"] == "" || errResp.Fields["email"] == "" {
+		t.Errorf("fields = %v", errResp.Fields)
+	}
+
+	resp = doJSON(t, http.MethodPost, srv.URL+"/users", `{"name":`, nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("잘못된 JSON: status %d, want 400", resp.StatusCode)
+	}
+	resp = doJSON(t, http.MethodPut, srv.URL+"/users/999", `{"name":"X","email":"x@example.com"}`, nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("없는 사용자 수정: status %d, want 404", resp.StatusCode)
+	}
+}
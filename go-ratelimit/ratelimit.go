@@ -0,0 +1,255 @@
+// Package ratelimit 은 클라이언트(API 키 또는 IP)와 라우트별 토큰 버킷 레이트 리밋과
+// Badger 에 저장하는 라우트별 초당 전체 한도를 HTTP 핸들러에 적용합니다
+package ratelimit
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+// KeyPrefix 는 전체 한도 카운터 키의 접두사입니다 (ratelimit:<route>:<unix 초>)
+const KeyPrefix = "ratelimit:"
+
+// 오래 쓰지 않은 버킷을 정리하는 주기
+const bucketSweepInterval = time.Minute
+
+// Limit 은 토큰 버킷 설정입니다 (초당 Rate 개씩 채워지고 최대 Burst 개까지 모임)
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimit 은 "10:20" (초당 요청 수:버스트) 형식을 파싱합니다. 버스트를 생략하면 rate 를 올림한 값을 사용
+func ParseLimit(s string) (Limit, error) {
+	rateStr, burstStr, hasBurst := strings.Cut(strings.TrimSpace(s), ":")
+	rate, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || rate <= 0 {
+		return Limit{}, fmt.Errorf("잘못된 한도 %q: 초당 요청 수는 0보다 커야 합니다", s)
+	}
+	l := Limit{Rate: rate, Burst: int(math.Ceil(rate))}
+	if hasBurst {
+		if l.Burst, err = strconv.Atoi(burstStr); err != nil || l.Burst <= 0 {
+			return Limit{}, fmt.Errorf("잘못된 한도 %q: 버스트는 1 이상의 정수여야 합니다", s)
+		}
+	}
+	return l, nil
+}
+
+// ParseLimits 는 "name=rate:burst,..." 형식을 파싱합니다 (라우트별, API 키별 한도)
+func ParseLimits(s string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		name, spec, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("잘못된 한도 %q: name=rate:burst 형식이어야 합니다", part)
+		}
+		l, err := ParseLimit(spec)
+		if err != nil {
+			return nil, err
+		}
+		limits[strings.TrimSpace(name)] = l
+	}
+	return limits, nil
+}
+
+// 토큰 버킷
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// 토큰을 하나 사용합니다. 부족하면 다음 토큰이 채워질 때까지 기다릴 시간을 반환
+func (b *tokenBucket) take(l Limit, now time.Time) (ok bool, remaining int, wait time.Duration) {
+	b.tokens = math.Min(float64(l.Burst), b.tokens+now.Sub(b.last).Seconds()*l.Rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, 0, time.Duration((1 - b.tokens) / l.Rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, int(b.tokens), 0
+}
+
+// Limiter 는 클라이언트(API 키 또는 IP)와 라우트별로 요청 수를 제한합니다
+type Limiter struct {
+	defaultLimit Limit            // 클라이언트별 기본 한도 (Rate 가 0 이면 제한 없음)
+	routeLimits  map[string]Limit // 라우트별 클라이언트 한도
+	clientLimits map[string]Limit // 등록된 API 키별 한도 (라우트 한도보다 우선)
+	clusterRate  int              // 라우트별 초당 전체 요청 수 (0 이면 사용 안 함, Badger 에 카운터 저장)
+	db           func() *badger.DB
+	reject       http.HandlerFunc
+
+	// Now 는 현재 시각입니다 (테스트에서 고정)
+	Now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// New 는 Limiter 를 만듭니다
+// db 는 전체 한도 카운터를 저장할 DB 를 반환하고 (clusterRate 가 0 이면 nil 가능),
+// reject 는 한도를 넘은 요청에 429 응답 본문을 씁니다 (Retry-After 등 헤더는 이미 설정됨)
+func New(def Limit, routes, clients map[string]Limit, clusterRate int, db func() *badger.DB, reject http.HandlerFunc) *Limiter {
+	return &Limiter{
+		defaultLimit: def,
+		routeLimits:  routes,
+		clientLimits: clients,
+		clusterRate:  clusterRate,
+		db:           db,
+		reject:       reject,
+		Now:          time.Now,
+		buckets:      make(map[string]*tokenBucket),
+	}
+}
+
+// FromFlags 는 명령행 플래그 값으로 Limiter 를 만듭니다 (모두 비어 있으면 nil: 제한 없음)
+// rate 는 "rate:burst", routes 와 clients 는 "name=rate:burst,..." 형식이며,
+// 기본 한도가 없으면 라우트/API 키 한도만 적용합니다
+func FromFlags(rate, routes, clients string, clusterRate int, db func() *badger.DB, reject http.HandlerFunc) (*Limiter, error) {
+	if rate == "" && routes == "" && clients == "" && clusterRate <= 0 {
+		return nil, nil
+	}
+	var def Limit
+	var err error
+	if rate != "" {
+		if def, err = ParseLimit(rate); err != nil {
+			return nil, err
+		}
+	}
+	routeLimits, err := ParseLimits(routes)
+	if err != nil {
+		return nil, err
+	}
+	clientLimits, err := ParseLimits(clients)
+	if err != nil {
+		return nil, err
+	}
+	return New(def, routeLimits, clientLimits, clusterRate, db, reject), nil
+}
+
+// 요청한 클라이언트와 적용할 한도를 정합니다
+// 등록된 X-API-Key 는 키 단위로, 그 외에는 IP 단위로 제한 (임의의 키로 한도를 우회하지 못하도록 함)
+func (l *Limiter) resolve(r *http.Request, route string) (string, Limit) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		if limit, ok := l.clientLimits[key]; ok {
+			return "key:" + key, limit
+		}
+	}
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if limit, ok := l.routeLimits[route]; ok {
+		return "ip:" + ip, limit
+	}
+	return "ip:" + ip, l.defaultLimit
+}
+
+// 클라이언트의 토큰 버킷에서 토큰을 하나 사용합니다
+func (l *Limiter) takeLocal(route, client string, limit Limit, now time.Time) (bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// 가득 찼을 시간이 지난 버킷은 새로 만든 것과 같으므로 정리
+	if now.Sub(l.lastSweep) > bucketSweepInterval {
+		for k, b := range l.buckets {
+			if now.Sub(b.last) > bucketSweepInterval {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	key := route + " " + client
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+	return b.take(limit, now)
+}
+
+// 라우트의 전체 한도(초 단위 고정 구간)에서 요청 하나를 사용합니다 (트랜잭션 충돌 시 다시 시도)
+// Badger 디렉터리는 한 프로세스만 열 수 있으므로, 한도는 프로세스 안의 모든 클라이언트를 합쳐 적용됩니다
+func (l *Limiter) takeCluster(route string, now time.Time) (bool, time.Duration, error) {
+	key := []byte(fmt.Sprintf("%s%s:%d", KeyPrefix, route, now.Unix()))
+	for attempt := 0; ; attempt++ {
+		allowed := false
+		err := l.db().Update(func(txn *badger.Txn) error {
+			var count uint64
+			item, err := txn.Get(key)
+			switch {
+			case err == badger.ErrKeyNotFound:
+			case err != nil:
+				return err
+			default:
+				if err := item.Value(func(val []byte) error {
+					count = binary.BigEndian.Uint64(val)
+					return nil
+				}); err != nil {
+					return err
+				}
+			}
+			if count >= uint64(l.clusterRate) {
+				return nil
+			}
+			allowed = true
+			val := binary.BigEndian.AppendUint64(nil, count+1)
+			// 이전 카운트는 필요 없으므로 DB 가 여러 버전을 보관하도록 설정되어 있어도 압축할 때 버림
+			return txn.SetEntry(badger.NewEntry(key, val).WithTTL(time.Minute).WithDiscard())
+		})
+		if err == badger.ErrConflict && attempt < 10 {
+			continue
+		}
+		if err != nil {
+			return false, 0, err
+		}
+		// 다음 구간(다음 초)까지 대기
+		return allowed, now.Truncate(time.Second).Add(time.Second).Sub(now), nil
+	}
+}
+
+// Wrap 은 핸들러에 레이트 리밋을 적용합니다 (한도를 넘으면 Retry-After 를 설정하고 reject 로 429 응답)
+// nil Limiter 는 핸들러를 그대로 반환합니다
+func (l *Limiter) Wrap(route string, h http.HandlerFunc) http.HandlerFunc {
+	if l == nil {
+		return h
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		now := l.Now()
+		ok, wait := true, time.Duration(0)
+		if client, limit := l.resolve(r, route); limit.Rate > 0 {
+			var remaining int
+			ok, remaining, wait = l.takeLocal(route, client, limit, now)
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		}
+		if ok && l.clusterRate > 0 {
+			var err error
+			if ok, wait, err = l.takeCluster(route, now); err != nil {
+				// 카운터를 읽지 못해도 서비스는 계속 (클라이언트별 한도는 적용됨)
+				log.Printf("cluster rate limit: %v", err)
+				ok = true
+			}
+		}
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			l.reject(w, r)
+			return
+		}
+		h(w, r)
+	}
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	limit := Limit{Rate: 2, Burst: 3}
+	b := &tokenBucket{tokens: 3, last: start}
+
+	for i := 0; i < 3; i++ {
+		if ok, remaining, _ := b.take(limit, start); !ok || remaining != 2-i {
+			t.Fatalf("%d번째 요청: ok %v, remaining %d", i+1, ok, remaining)
+		}
+	}
+	ok, _, wait := b.take(limit, start)
+	if ok || wait != 500*time.Millisecond {
+		t.Fatalf("버킷이 비었는데 ok %v, wait %v", ok, wait)
+	}
+	// 0.5초 뒤 토큰 1개 충전
+	if ok, _, _ := b.take(limit, start.Add(500*time.Millisecond)); !ok {
+		t.Fatal("충전 후에도 거절됨")
+	}
+	// 오래 지나도 버스트 이상은 쌓이지 않음
+	if _, remaining, _ := b.take(limit, start.Add(time.Hour)); remaining != 2 {
+		t.Errorf("remaining = %d, want 2", remaining)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits("/users=5:10, /users/{id}=2.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limits["/users"] != (Limit{5, 10}) || limits["/users/{id}"] != (Limit{2.5, 3}) {
+		t.Errorf("limits = %v", limits)
+	}
+	for _, bad := range []string{"/users", "/users=0:1", "/users=1:0", "/users=x"} {
+		if _, err := ParseLimits(bad); err == nil {
+			t.Errorf("ParseLimits(%q) 에러 없음", bad)
+		}
+	}
+}
+
+func TestClusterCounter(t *testing.T) {
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	store := func() *badger.DB { return db }
+
+	// 같은 Badger 를 쓰는 두 Limiter 가 초당 한도를 함께 사용
+	now := time.Unix(1_700_000_000, 250_000_000)
+	a := New(Limit{}, nil, nil, 2, store, nil)
+	b := New(Limit{}, nil, nil, 2, store, nil)
+	results := []bool{}
+	for _, l := range []*Limiter{a, b, a} {
+		ok, wait, err := l.takeCluster("/get", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, ok)
+		if !ok && wait != 750*time.Millisecond {
+			t.Errorf("wait = %v, want 750ms", wait)
+		}
+	}
+	if !results[0] || !results[1] || results[2] {
+		t.Fatalf("results = %v, want [true true false]", results)
+	}
+	if ok, _, _ := a.takeCluster("/get", now.Add(time.Second)); !ok {
+		t.Error("다음 구간에서도 거절됨")
+	}
+}